@@ -0,0 +1,22 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"test/internal/config"
+)
+
+// runConfig implements "pr-service config print", which writes the effective
+// configuration as YAML with secrets redacted.
+func runConfig(cfg config.Config, args []string) error {
+	if len(args) != 1 || args[0] != "print" {
+		return errors.New("usage: pr-service config print")
+	}
+
+	out, err := cfg.Redacted().YAML()
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
@@ -1,73 +1,448 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"test/internal/api"
+	"test/internal/app/auth"
 	"test/internal/app/handler"
+	"test/internal/app/health"
+	"test/internal/app/integration"
+	"test/internal/app/middleware"
+	"test/internal/config"
+	"test/internal/domain/model"
+	"test/internal/domain/repository"
 	"test/internal/domain/service"
+	"test/internal/infrastructure/metrics"
+	"test/internal/infrastructure/persistence/memory"
+	"test/internal/infrastructure/persistence/postgres/migrations"
+	"test/internal/infrastructure/persistence/postgres/pg_migrate"
 	"test/internal/infrastructure/persistence/postgres/pg_repository"
+	"test/internal/infrastructure/tracing"
+	"test/internal/infrastructure/webhook"
+	"test/internal/logging"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	_ "github.com/lib/pq"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"github.com/uptrace/opentelemetry-go-extra/otelsql"
 )
 
 func main() {
-	dsn := os.Getenv("DATABASE_URL")
-	if dsn == "" {
-		log.Fatal("DATABASE_URL is not set")
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("invalid config", err)
+	}
+	slog.SetDefault(logging.New(os.Stdout, cfg.Log.Level))
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			if err := runMigrate(cfg, os.Args[2:]); err != nil {
+				fatal("migrate", err)
+			}
+			return
+		case "config":
+			if err := runConfig(cfg, os.Args[2:]); err != nil {
+				fatal("config", err)
+			}
+			return
+		default:
+			fatal("unknown command", fmt.Errorf("%q", os.Args[1]))
+		}
+	}
+
+	if err := serve(cfg); err != nil {
+		fatal("server error", err)
+	}
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// serve runs the API until SIGINT or SIGTERM, then fails readiness for
+// cfg.Server.ShutdownDelay, stops accepting requests, waits up to
+// cfg.Server.ShutdownTimeout for in-flight ones, stops the background workers
+// and only then releases the storage.
+func serve(cfg config.Config) error {
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName: cfg.Tracing.ServiceName,
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		File:        cfg.Tracing.File,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		return err
 	}
+	// Runs last, after the server and the workers have stopped, so their final
+	// spans are flushed too.
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("tracing: flush spans", "error", err)
+		}
+	}()
 
-	db, err := sql.Open("postgres", dsn)
+	repos, closeRepos, err := openRepositories(cfg)
 	if err != nil {
-		log.Fatalf("failed to open db: %v", err)
+		return fmt.Errorf("open storage: %w", err)
 	}
-	defer db.Close()
+	defer closeRepos()
 
-	userRepo := pg_repository.NewUserRepository(db)
-	teamRepo := pg_repository.NewTeamRepository(db, userRepo)
-	prRepo := pg_repository.NewPrRepository(db)
+	userRepo := repos.user
+	teamRepo := repos.team
+	prRepo := repos.pr
+	txManager := repos.tx
+	webhookRepo := repos.webhook
+	outboxRepo := repos.outbox
+	idempotencyRepo := repos.idempotency
+	tokenRepo := repos.token
 
 	userService := service.NewUserService(userRepo)
 	teamService := service.NewTeamService(teamRepo, userRepo, prRepo)
-	prService := service.NewPrService(prRepo, userRepo, teamRepo)
+	registry, selector := newReviewerSelector(prRepo, cfg.Reviewers)
+	var prMetrics service.Metrics = service.NopMetrics{}
+	var promMetrics *metrics.Metrics
+	if cfg.Features.Metrics {
+		promMetrics = metrics.New(prRepo.CountOpenReviews)
+		prMetrics = promMetrics
+	}
+	prService := service.NewPrService(prRepo, userRepo, teamRepo, txManager, registry, selector, prMetrics)
+	webhookService := service.NewWebhookService(webhookRepo)
+	tokenService := service.NewTokenService(tokenRepo, userRepo)
+
+	// Workers get their own context: they keep running while the server drains,
+	// since in-flight requests may still write to the outbox.
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	defer func() {
+		stopWorkers()
+		workers.Wait()
+	}()
+
+	checker := health.NewChecker(2 * time.Second)
+	if repos.db != nil {
+		migrator, err := pg_migrate.New(repos.db, migrations.FS)
+		if err != nil {
+			return err
+		}
+		checker.Add("database", repos.db.PingContext)
+		checker.Add("migrations", migrator.CheckCurrent)
+	}
+
+	if cfg.Features.Webhooks {
+		dispatcher := webhook.NewDispatcher(outboxRepo, webhook.DefaultConfig())
+		workers.Go(func() { dispatcher.Run(workersCtx) })
+		checker.Add("webhook_dispatcher", dispatcher.Check)
+	}
+
+	var middlewares []api.MiddlewareFunc
+	if cfg.Features.Idempotency {
+		middlewares = append(middlewares, middleware.Idempotency(idempotencyRepo, cfg.Idempotency.TTL, cfg.Idempotency.Lease))
+		purger := health.NewHeartbeat(3 * idempotencyPurgeInterval)
+		workers.Go(func() { purgeIdempotencyKeys(workersCtx, idempotencyRepo, idempotencyPurgeInterval, purger) })
+		checker.Add("idempotency_purger", purger.Check)
+	}
 
 	userHandler := handler.NewUserHandler(userService, prService)
 	teamHandler := handler.NewTeamHandler(teamService)
 	prHandler := handler.NewPrHandler(prService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	tokenHandler := handler.NewTokenHandler(tokenService)
 
-	apiHandler := handler.NewAPIHandler(teamHandler, userHandler, prHandler)
+	apiHandler := handler.NewAPIHandler(teamHandler, userHandler, prHandler, webhookHandler, tokenHandler)
 	r := chi.NewRouter()
-	api.HandlerFromMux(apiHandler, r)
-	r.Get("/swagger/doc.json", func(w http.ResponseWriter, r *http.Request) {
-		swagger, err := api.GetSwagger()
+	r.Use(middleware.RequestID, tracing.Middleware, middleware.AccessLog)
+	if promMetrics != nil {
+		r.Use(promMetrics.Middleware)
+		r.Handle("/metrics", promMetrics.Handler())
+	}
+	spec, err := api.GetSwagger()
+	if err != nil {
+		return fmt.Errorf("load openapi spec: %w", err)
+	}
+	// The per-IP rate limit and load shedding run first, so that neither a
+	// flood of unauthenticated requests nor an overloaded service costs a token
+	// lookup. Authentication comes next, so anonymous callers learn nothing
+	// about the shape of valid requests and the rate limiter knows who the
+	// caller is.
+	var apiMiddlewares []func(http.Handler) http.Handler
+	if cfg.Features.RateLimit {
+		apiMiddlewares = append(apiMiddlewares, middleware.IPRateLimiter(middleware.RateLimit{RPS: cfg.RateLimit.IPRPS, Burst: cfg.RateLimit.IPBurst}))
+	}
+	// In-memory storage has no connection pool to saturate.
+	if cfg.Features.LoadShedding && repos.db != nil {
+		apiMiddlewares = append(apiMiddlewares, middleware.LoadShedder(repos.db.Stats, cfg.LoadShedding.MaxWait))
+	}
+	if cfg.Features.Auth {
+		authn, err := middleware.Auth(spec, newAuthenticator(cfg.Auth, tokenService))
 		if err != nil {
-			http.Error(w, "Failed to load swagger spec: "+err.Error(), http.StatusInternalServerError)
-			return
+			return err
+		}
+		apiMiddlewares = append(apiMiddlewares, authn)
+	}
+	if cfg.Features.RateLimit {
+		apiMiddlewares = append(apiMiddlewares, newRateLimiter(cfg.RateLimit))
+	}
+	if cfg.Features.Validation {
+		validation, err := middleware.Validation(spec, int64(cfg.Validation.MaxBodyBytes), cfg.Validation.Responses)
+		if err != nil {
+			return err
 		}
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(swagger)
+		apiMiddlewares = append(apiMiddlewares, validation)
+	}
+	r.Group(func(r chi.Router) {
+		r.Use(apiMiddlewares...)
+		api.HandlerWithOptions(apiHandler, api.ChiServerOptions{
+			BaseRouter:       r,
+			Middlewares:      middlewares,
+			ErrorHandlerFunc: handler.WriteRequestError,
+		})
 	})
-	r.Get("/swagger/*", httpSwagger.WrapHandler)
 
-	hostname := os.Getenv("HOSTNAME")
-	if hostname == "" {
-		hostname = "0.0.0.0"
+	healthHandler := handler.NewHealthHandler(checker)
+	r.Get("/healthz", healthHandler.GetHealthz)
+	r.Get("/readyz", healthHandler.GetReadyz)
+
+	// Provider webhooks carry the providers' own payloads, so they are mounted
+	// outside the generated API and only when their secret is configured.
+	if cfg.Features.Integrations {
+		ic := cfg.Integrations
+		integrationHandler := handler.NewIntegrationHandler(prService, integration.UserMap(ic.UserMap), ic.GitHubWebhookSecret, ic.GitLabWebhookToken)
+		if ic.GitHubWebhookSecret != "" {
+			r.Post("/integrations/github/webhook", integrationHandler.PostGitHubWebhook)
+		}
+		if ic.GitLabWebhookToken != "" {
+			r.Post("/integrations/gitlab/webhook", integrationHandler.PostGitLabWebhook)
+		}
 	}
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+
+	if cfg.Features.Swagger {
+		r.Get("/swagger/doc.json", func(w http.ResponseWriter, r *http.Request) {
+			swagger, err := api.GetSwagger()
+			if err != nil {
+				http.Error(w, "Failed to load swagger spec: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(swagger)
+		})
+		r.Get("/swagger/*", httpSwagger.WrapHandler)
 	}
 
-	log.Println("server running on :" + port)
-	hostWithPort := fmt.Sprintf("%s:%s", hostname, port)
-	err = http.ListenAndServe(hostWithPort, r)
+	srv := &http.Server{
+		Addr:              cfg.Server.Addr(),
+		Handler:           r,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("server running", "addr", srv.Addr)
+		serverErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
+	}
+	stop()
+
+	checker.Drain()
+	if cfg.Server.ShutdownDelay > 0 {
+		slog.Info("shutting down, failing readiness", "delay", cfg.Server.ShutdownDelay.String())
+		time.Sleep(cfg.Server.ShutdownDelay)
+	}
+
+	slog.Info("shutting down, draining requests", "timeout", cfg.Server.ShutdownTimeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	slog.Info("server stopped")
+	return nil
+}
+
+type repositories struct {
+	user        repository.UserRepository
+	team        repository.TeamRepository
+	pr          repository.PrRepository
+	tx          repository.TxManager
+	webhook     repository.WebhookRepository
+	outbox      repository.OutboxRepository
+	idempotency repository.IdempotencyRepository
+	token       repository.TokenRepository
+	// db is the Postgres pool, nil for the memory storage.
+	db *sql.DB
+}
+
+// openRepositories builds the repositories for the configured storage backend:
+// "postgres" connects to the configured database, "memory" keeps all data in the
+// process and loses it on restart. The returned func releases the backend.
+func openRepositories(cfg config.Config) (*repositories, func(), error) {
+	switch cfg.Storage {
+	case "postgres":
+		db, err := openDB(cfg.Database)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cfg.Database.MigrateOnStart {
+			if err := migrateUp(context.Background(), db); err != nil {
+				db.Close()
+				return nil, nil, fmt.Errorf("migrate: %w", err)
+			}
+		}
+
+		userRepo := pg_repository.NewUserRepository(db)
+		return &repositories{
+			user:        userRepo,
+			team:        pg_repository.NewTeamRepository(db, userRepo),
+			pr:          pg_repository.NewPrRepository(db),
+			tx:          pg_repository.NewTxManager(db),
+			webhook:     pg_repository.NewWebhookRepository(db),
+			outbox:      pg_repository.NewOutboxRepository(db),
+			idempotency: pg_repository.NewIdempotencyRepository(db),
+			token:       pg_repository.NewTokenRepository(db),
+			db:          db,
+		}, func() { db.Close() }, nil
+	case "memory":
+		store := memory.NewStore()
+		userRepo := memory.NewUserRepository(store)
+		return &repositories{
+			user:        userRepo,
+			team:        memory.NewTeamRepository(store, userRepo),
+			pr:          memory.NewPrRepository(store),
+			tx:          memory.NewTxManager(store),
+			webhook:     memory.NewWebhookRepository(store),
+			outbox:      memory.NewOutboxRepository(store),
+			idempotency: memory.NewIdempotencyRepository(store),
+			token:       memory.NewTokenRepository(store),
+		}, func() {}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage %q", cfg.Storage)
+	}
+}
+
+func openDB(cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := otelsql.Open("postgres", cfg.URL, otelsql.WithDBSystem("postgresql"))
 	if err != nil {
-		log.Fatalf("server error: %v", err)
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return db, nil
+}
+
+// newReviewerSelector builds the reviewer selection policy: cfg.Strategy is the
+// default strategy, cfg.TeamStrategies overrides it per team and cfg.Weights
+// configures the weighted strategy. A strategy stored in team settings takes
+// precedence over both. The config is validated by config.Load.
+func newReviewerSelector(prRepo repository.PrRepository, cfg config.ReviewersConfig) (service.SelectorRegistry, service.ReviewerSelector) {
+	registry := service.NewSelectorRegistry(prRepo, cfg.Weights)
+
+	teams := make(map[string]service.ReviewerSelector)
+	for team, strategy := range cfg.TeamStrategies {
+		teams[team] = registry[model.SelectionStrategy(strategy)]
+	}
+
+	return registry, service.NewPerTeamSelector(registry[model.SelectionStrategy(cfg.Strategy)], teams)
+}
+
+// newAuthenticator accepts the tokens of the token service and, when a key set
+// is configured, JWTs of the identity provider.
+func newAuthenticator(cfg config.AuthConfig, tokens *service.TokenService) auth.Authenticator {
+	authenticator := auth.Authenticator(auth.NewTokenAuthenticator(tokens, cfg.AdminToken))
+
+	var keys *auth.KeySet
+	switch {
+	case cfg.JWKSFile != "":
+		keys = auth.NewFileKeySet(cfg.JWKSFile, cfg.JWKSRefresh)
+	case cfg.JWKSURL != "":
+		keys = auth.NewURLKeySet(cfg.JWKSURL, cfg.JWKSRefresh)
+	default:
+		return authenticator
+	}
+
+	roles := make(map[string]model.Role, len(cfg.JWTRoles))
+	for value, role := range cfg.JWTRoles {
+		roles[value] = model.Role(role)
+	}
+	jwt := auth.NewJWTAuthenticator(keys, auth.JWTConfig{
+		Issuer:    cfg.JWTIssuer,
+		Audience:  cfg.JWTAudience,
+		UserClaim: cfg.JWTUserClaim,
+		RoleClaim: cfg.JWTRoleClaim,
+		Roles:     roles,
+	})
+	return auth.Chain(jwt, authenticator)
+}
+
+// newRateLimiter builds the rate limiter from the default limits and the
+// per-group overrides, which may set only one of rps and burst.
+func newRateLimiter(cfg config.RateLimitConfig) func(http.Handler) http.Handler {
+	def := middleware.RateLimit{RPS: cfg.RPS, Burst: cfg.Burst}
+
+	groups := make(map[string]middleware.RateLimit)
+	for prefix, rps := range cfg.GroupRPS {
+		limit := def
+		limit.RPS = rps
+		groups[prefix] = limit
+	}
+	for prefix, burst := range cfg.GroupBurst {
+		limit, ok := groups[prefix]
+		if !ok {
+			limit = def
+		}
+		limit.Burst = burst
+		groups[prefix] = limit
+	}
+	return middleware.RateLimiter(def, groups)
+}
+
+// idempotencyPurgeInterval is how often expired idempotency keys are deleted.
+const idempotencyPurgeInterval = time.Hour
+
+// purgeIdempotencyKeys deletes expired idempotency keys every interval until ctx
+// is cancelled, beating hb after every pass.
+func purgeIdempotencyKeys(ctx context.Context, repo repository.IdempotencyRepository, interval time.Duration, hb *health.Heartbeat) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	hb.Start()
+	defer hb.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := repo.DeleteExpired(ctx); err != nil {
+				slog.ErrorContext(ctx, "idempotency: delete expired keys", "error", err)
+			}
+			hb.Beat()
+		}
 	}
 }
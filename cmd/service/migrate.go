@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"test/internal/config"
+	"test/internal/infrastructure/persistence/postgres/migrations"
+	"test/internal/infrastructure/persistence/postgres/pg_migrate"
+)
+
+const migrateUsage = "usage: pr-service migrate up | down [n] | status"
+
+// runMigrate implements "pr-service migrate up|down [n]|status" against the
+// configured database. down rolls back one migration unless n is given.
+func runMigrate(cfg config.Config, args []string) error {
+	if len(args) == 0 || (args[0] != "up" && args[0] != "down" && args[0] != "status") {
+		return errors.New(migrateUsage)
+	}
+
+	if cfg.Storage != "postgres" {
+		return fmt.Errorf("storage %q has no migrations", cfg.Storage)
+	}
+
+	db, err := openDB(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	m, err := pg_migrate.New(db, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		n, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("applied %d migration(s)\n", n)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		n, err := m.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rolled back %d migration(s)\n", n)
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("version: %d", status.Version)
+		if status.Dirty {
+			fmt.Print(" (dirty)")
+		}
+		fmt.Println()
+		for _, mig := range status.Migrations {
+			state := "applied"
+			if mig.Version > status.Version {
+				state = "pending"
+			}
+			fmt.Printf("%03d_%s\t%s\n", mig.Version, mig.Name, state)
+		}
+	}
+	return nil
+}
+
+// migrateUp applies pending migrations.
+func migrateUp(ctx context.Context, db *sql.DB) error {
+	m, err := pg_migrate.New(db, migrations.FS)
+	if err != nil {
+		return err
+	}
+	_, err = m.Up(ctx)
+	return err
+}
@@ -6,6 +6,7 @@ package api
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
@@ -19,49 +20,211 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for ApiTokenRole.
+const (
+	ApiTokenRoleADMIN ApiTokenRole = "ADMIN"
+	ApiTokenRoleUSER  ApiTokenRole = "USER"
+)
+
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	FORBIDDEN            ErrorResponseErrorCode = "FORBIDDEN"
+	IDEMPOTENCYKEYREUSED ErrorResponseErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	INTERNAL             ErrorResponseErrorCode = "INTERNAL"
+	INVALIDSTATUS        ErrorResponseErrorCode = "INVALID_STATUS"
+	NOCANDIDATE          ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTAPPROVED          ErrorResponseErrorCode = "NOT_APPROVED"
+	NOTASSIGNED          ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND             ErrorResponseErrorCode = "NOT_FOUND"
+	OVERLOADED           ErrorResponseErrorCode = "OVERLOADED"
+	PREXISTS             ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED             ErrorResponseErrorCode = "PR_MERGED"
+	PRNOTOPEN            ErrorResponseErrorCode = "PR_NOT_OPEN"
+	RATELIMITED          ErrorResponseErrorCode = "RATE_LIMITED"
+	REQUESTINPROGRESS    ErrorResponseErrorCode = "REQUEST_IN_PROGRESS"
+	TEAMEXISTS           ErrorResponseErrorCode = "TEAM_EXISTS"
+	UNAUTHORIZED         ErrorResponseErrorCode = "UNAUTHORIZED"
+	USERHASOPENPRS       ErrorResponseErrorCode = "USER_HAS_OPEN_PRS"
+	VALIDATIONERROR      ErrorResponseErrorCode = "VALIDATION_ERROR"
+	VERSIONMISMATCH      ErrorResponseErrorCode = "VERSION_MISMATCH"
+)
+
+// Defines values for PrEventFromStatus.
+const (
+	PrEventFromStatusCLOSED PrEventFromStatus = "CLOSED"
+	PrEventFromStatusDRAFT  PrEventFromStatus = "DRAFT"
+	PrEventFromStatusMERGED PrEventFromStatus = "MERGED"
+	PrEventFromStatusOPEN   PrEventFromStatus = "OPEN"
+)
+
+// Defines values for PrEventToStatus.
+const (
+	PrEventToStatusCLOSED PrEventToStatus = "CLOSED"
+	PrEventToStatusDRAFT  PrEventToStatus = "DRAFT"
+	PrEventToStatusMERGED PrEventToStatus = "MERGED"
+	PrEventToStatusOPEN   PrEventToStatus = "OPEN"
+)
+
+// Defines values for PrEventType.
+const (
+	PrEventTypeCREATED            PrEventType = "CREATED"
+	PrEventTypeMERGED             PrEventType = "MERGED"
+	PrEventTypeREVIEWERASSIGNED   PrEventType = "REVIEWER_ASSIGNED"
+	PrEventTypeREVIEWERREASSIGNED PrEventType = "REVIEWER_REASSIGNED"
+	PrEventTypeREVIEWSUBMITTED    PrEventType = "REVIEW_SUBMITTED"
+	PrEventTypeSTATUSCHANGED      PrEventType = "STATUS_CHANGED"
 )
 
 // Defines values for PullRequestStatus.
 const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
 // Defines values for PullRequestShortStatus.
 const (
-	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
-	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
+	CLOSED PullRequestShortStatus = "CLOSED"
+	DRAFT  PullRequestShortStatus = "DRAFT"
+	MERGED PullRequestShortStatus = "MERGED"
+	OPEN   PullRequestShortStatus = "OPEN"
+)
+
+// Defines values for ReviewDecision.
+const (
+	ReviewDecisionAPPROVED         ReviewDecision = "APPROVED"
+	ReviewDecisionCHANGESREQUESTED ReviewDecision = "CHANGES_REQUESTED"
+)
+
+// Defines values for TeamSettingsSelectionStrategy.
+const (
+	LeastLoaded TeamSettingsSelectionStrategy = "least_loaded"
+	Random      TeamSettingsSelectionStrategy = "random"
+	RoundRobin  TeamSettingsSelectionStrategy = "round_robin"
+	Weighted    TeamSettingsSelectionStrategy = "weighted"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusDEAD      WebhookDeliveryStatus = "DEAD"
+	WebhookDeliveryStatusDELIVERED WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryStatusPENDING   WebhookDeliveryStatus = "PENDING"
+)
+
+// Defines values for PostAdminTokensCreateJSONBodyRole.
+const (
+	PostAdminTokensCreateJSONBodyRoleADMIN PostAdminTokensCreateJSONBodyRole = "ADMIN"
+	PostAdminTokensCreateJSONBodyRoleUSER  PostAdminTokensCreateJSONBodyRole = "USER"
+)
+
+// Defines values for PostPullRequestReviewJSONBodyDecision.
+const (
+	PostPullRequestReviewJSONBodyDecisionAPPROVED         PostPullRequestReviewJSONBodyDecision = "APPROVED"
+	PostPullRequestReviewJSONBodyDecisionCHANGESREQUESTED PostPullRequestReviewJSONBodyDecision = "CHANGES_REQUESTED"
+)
+
+// Defines values for GetWebhooksDeliveriesParamsStatus.
+const (
+	GetWebhooksDeliveriesParamsStatusDEAD      GetWebhooksDeliveriesParamsStatus = "DEAD"
+	GetWebhooksDeliveriesParamsStatusDELIVERED GetWebhooksDeliveriesParamsStatus = "DELIVERED"
+	GetWebhooksDeliveriesParamsStatusPENDING   GetWebhooksDeliveriesParamsStatus = "PENDING"
 )
 
+// ApiToken defines model for ApiToken.
+type ApiToken struct {
+	CreatedAt time.Time    `json:"created_at"`
+	Name      string       `json:"name"`
+	Role      ApiTokenRole `json:"role"`
+
+	// Token Сам токен, возвращается только при создании; сервис хранит лишь его хэш
+	Token   *string `json:"token,omitempty"`
+	TokenId string  `json:"token_id"`
+
+	// UserId Пользователь, от имени которого действует токен; обязателен для роли USER
+	UserId *string `json:"user_id,omitempty"`
+}
+
+// ApiTokenRole defines model for ApiToken.Role.
+type ApiTokenRole string
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
-		Code    ErrorResponseErrorCode `json:"code"`
-		Message string                 `json:"message"`
+		Code ErrorResponseErrorCode `json:"code"`
+
+		// CorrelationId Идентификатор запроса (`X-Request-ID`) для поиска в логах; передаётся для внутренних ошибок
+		CorrelationId *string `json:"correlation_id,omitempty"`
+
+		// Details Сущности, к которым относится ошибка, например `pull_request_id`
+		Details *map[string]string `json:"details,omitempty"`
+		Message string             `json:"message"`
 	} `json:"error"`
 }
 
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// FallbackReviewer defines model for FallbackReviewer.
+type FallbackReviewer struct {
+	// TeamName Резервная команда, из которой взят ревьювер
+	TeamName string `json:"team_name"`
+	UserId   string `json:"user_id"`
+}
+
+// PrEvent defines model for PrEvent.
+type PrEvent struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Details Резервная команда ревьювера, решение ревью или причина слияния без одобрений
+	Details    *string            `json:"details,omitempty"`
+	EventId    int64              `json:"event_id"`
+	FromStatus *PrEventFromStatus `json:"from_status,omitempty"`
+
+	// OldReviewerId Снятый ревьювер (для REVIEWER_REASSIGNED)
+	OldReviewerId *string `json:"old_reviewer_id,omitempty"`
+
+	// ReviewerId Назначенный ревьювер, новый ревьювер при переназначении или автор решения
+	ReviewerId *string          `json:"reviewer_id,omitempty"`
+	ToStatus   *PrEventToStatus `json:"to_status,omitempty"`
+	Type       PrEventType      `json:"type"`
+}
+
+// PrEventFromStatus defines model for PrEvent.FromStatus.
+type PrEventFromStatus string
+
+// PrEventToStatus defines model for PrEvent.ToStatus.
+type PrEventToStatus string
+
+// PrEventType defines model for PrEvent.Type.
+type PrEventType string
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
-	// AssignedReviewers user_id назначенных ревьюверов (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestId     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	// AssignedReviewers user_id назначенных ревьюверов (по умолчанию 0..2, задаётся настройками команды)
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AuthorId          string     `json:"author_id"`
+	CreatedAt         *time.Time `json:"createdAt"`
+
+	// FallbackReviewers Ревьюверы, взятые из резервных команд
+	FallbackReviewers *[]FallbackReviewer `json:"fallback_reviewers,omitempty"`
+
+	// MergeOverrideReason Причина слияния без необходимого числа одобрений
+	MergeOverrideReason *string    `json:"merge_override_reason"`
+	MergedAt            *time.Time `json:"mergedAt"`
+	PullRequestId       string     `json:"pull_request_id"`
+	PullRequestName     string     `json:"pull_request_name"`
+
+	// Reviews Последние решения назначенных ревьюверов
+	Reviews *[]Review         `json:"reviews,omitempty"`
+	Status  PullRequestStatus `json:"status"`
+
+	// Version Версия PR, увеличивается при каждом изменении
+	Version int64 `json:"version"`
 }
 
 // PullRequestStatus defines model for PullRequest.Status.
@@ -73,11 +236,22 @@ type PullRequestShort struct {
 	PullRequestId   string                 `json:"pull_request_id"`
 	PullRequestName string                 `json:"pull_request_name"`
 	Status          PullRequestShortStatus `json:"status"`
+	Version         int64                  `json:"version"`
 }
 
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// Review defines model for Review.
+type Review struct {
+	DecidedAt  time.Time      `json:"decided_at"`
+	Decision   ReviewDecision `json:"decision"`
+	ReviewerId string         `json:"reviewer_id"`
+}
+
+// ReviewDecision defines model for Review.Decision.
+type ReviewDecision string
+
 // Team defines model for Team.
 type Team struct {
 	Members  []TeamMember `json:"members"`
@@ -91,48 +265,221 @@ type TeamMember struct {
 	Username string `json:"username"`
 }
 
+// TeamSettings defines model for TeamSettings.
+type TeamSettings struct {
+	// AllowCrossTeam Разрешено ли добирать ревьюверов из других команд
+	AllowCrossTeam bool `json:"allow_cross_team"`
+
+	// FallbackTeams Команды, из которых по порядку добираются недостающие ревьюверы (при allow_cross_team)
+	FallbackTeams *[]string `json:"fallback_teams,omitempty"`
+
+	// MinApprovals Сколько одобрений нужно для слияния PR (по умолчанию 0, не больше reviewers_per_pr)
+	MinApprovals *int `json:"min_approvals,omitempty"`
+
+	// ReviewersPerPr Сколько ревьюверов назначать на PR
+	ReviewersPerPr int `json:"reviewers_per_pr"`
+
+	// SelectionStrategy Стратегия выбора ревьюверов; если не задана, используется стратегия по умолчанию
+	SelectionStrategy *TeamSettingsSelectionStrategy `json:"selection_strategy"`
+	TeamName          string                         `json:"team_name"`
+}
+
+// TeamSettingsSelectionStrategy Стратегия выбора ревьюверов; если не задана, используется стратегия по умолчанию
+type TeamSettingsSelectionStrategy string
+
 // User defines model for User.
 type User struct {
 	IsActive bool   `json:"is_active"`
 	TeamName string `json:"team_name"`
 	UserId   string `json:"user_id"`
 	Username string `json:"username"`
+
+	// Version Версия пользователя, увеличивается при каждом изменении
+	Version int64 `json:"version"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts      int                   `json:"attempts"`
+	DeliveredAt   *time.Time            `json:"delivered_at,omitempty"`
+	DeliveryId    int64                 `json:"delivery_id"`
+	EventId       int64                 `json:"event_id"`
+	EventType     string                `json:"event_type"`
+	LastError     *string               `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time            `json:"next_attempt_at,omitempty"`
+	Status        WebhookDeliveryStatus `json:"status"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// EventTypes Типы событий (например, pull_request.merged, user.deactivated); пустой список — все события
+	EventTypes []string `json:"event_types"`
+
+	// Secret Ключ подписи HMAC-SHA256, возвращается только при создании
+	Secret    *string `json:"secret,omitempty"`
+	Url       string  `json:"url"`
+	WebhookId string  `json:"webhook_id"`
 }
 
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// PullRequestIdQuery defines model for PullRequestIdQuery.
+type PullRequestIdQuery = string
+
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
-// UserIdQuery defines model for UserIdQuery.
-type UserIdQuery = string
+// WebhookIdQuery defines model for WebhookIdQuery.
+type WebhookIdQuery = string
+
+// Forbidden defines model for Forbidden.
+type Forbidden = ErrorResponse
+
+// ServiceUnavailable defines model for ServiceUnavailable.
+type ServiceUnavailable = ErrorResponse
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = ErrorResponse
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = ErrorResponse
+
+// ValidationError defines model for ValidationError.
+type ValidationError = ErrorResponse
+
+// PostAdminTokensCreateJSONBody defines parameters for PostAdminTokensCreate.
+type PostAdminTokensCreateJSONBody struct {
+	Name   string                            `json:"name"`
+	Role   PostAdminTokensCreateJSONBodyRole `json:"role"`
+	UserId *string                           `json:"user_id,omitempty"`
+}
+
+// PostAdminTokensCreateJSONBodyRole defines parameters for PostAdminTokensCreate.
+type PostAdminTokensCreateJSONBodyRole string
+
+// PostAdminTokensRevokeJSONBody defines parameters for PostAdminTokensRevoke.
+type PostAdminTokensRevokeJSONBody struct {
+	TokenId string `json:"token_id"`
+}
+
+// PostPullRequestCloseJSONBody defines parameters for PostPullRequestClose.
+type PostPullRequestCloseJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestCloseParams defines parameters for PostPullRequestClose.
+type PostPullRequestCloseParams struct {
+	// IfMatch ETag ресурса из предыдущего ответа; при несовпадении версии запрос отклоняется с кодом 412
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
 
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
-	AuthorId        string `json:"author_id"`
+	AuthorId string `json:"author_id"`
+
+	// Draft Создать PR в статусе DRAFT без ревьюверов
+	Draft           *bool  `json:"draft,omitempty"`
 	PullRequestId   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 }
 
+// GetPullRequestGetParams defines parameters for GetPullRequestGet.
+type GetPullRequestGetParams struct {
+	// PullRequestId Идентификатор PR
+	PullRequestId PullRequestIdQuery `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetPullRequestHistoryParams defines parameters for GetPullRequestHistory.
+type GetPullRequestHistoryParams struct {
+	// PullRequestId Идентификатор PR
+	PullRequestId PullRequestIdQuery `form:"pull_request_id" json:"pull_request_id"`
+}
+
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
 type PostPullRequestMergeJSONBody struct {
+	// OverrideReason Слить PR без необходимого числа одобрений, указав причину
+	OverrideReason *string `json:"override_reason,omitempty"`
+	PullRequestId  string  `json:"pull_request_id"`
+}
+
+// PostPullRequestMergeParams defines parameters for PostPullRequestMerge.
+type PostPullRequestMergeParams struct {
+	// IfMatch ETag ресурса из предыдущего ответа; при несовпадении версии запрос отклоняется с кодом 412
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PostPullRequestReadyJSONBody defines parameters for PostPullRequestReady.
+type PostPullRequestReadyJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestReadyParams defines parameters for PostPullRequestReady.
+type PostPullRequestReadyParams struct {
+	// IfMatch ETag ресурса из предыдущего ответа; при несовпадении версии запрос отклоняется с кодом 412
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
 // PostPullRequestReassignJSONBody defines parameters for PostPullRequestReassign.
 type PostPullRequestReassignJSONBody struct {
 	OldUserId     string `json:"old_user_id"`
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestReassignParams defines parameters for PostPullRequestReassign.
+type PostPullRequestReassignParams struct {
+	// IfMatch ETag ресурса из предыдущего ответа; при несовпадении версии запрос отклоняется с кодом 412
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PostPullRequestReopenJSONBody defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestReopenParams defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenParams struct {
+	// IfMatch ETag ресурса из предыдущего ответа; при несовпадении версии запрос отклоняется с кодом 412
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PostPullRequestReviewJSONBody defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBody struct {
+	Decision      PostPullRequestReviewJSONBodyDecision `json:"decision"`
+	PullRequestId string                                `json:"pull_request_id"`
+	ReviewerId    string                                `json:"reviewer_id"`
+}
+
+// PostPullRequestReviewParams defines parameters for PostPullRequestReview.
+type PostPullRequestReviewParams struct {
+	// IfMatch ETag ресурса из предыдущего ответа; при несовпадении версии запрос отклоняется с кодом 412
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PostPullRequestReviewJSONBodyDecision defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBodyDecision string
+
 // GetTeamGetParams defines parameters for GetTeamGet.
 type GetTeamGetParams struct {
 	// TeamName Уникальное имя команды
 	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
+// GetTeamSettingsGetParams defines parameters for GetTeamSettingsGet.
+type GetTeamSettingsGetParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+}
+
 // GetUsersGetReviewParams defines parameters for GetUsersGetReview.
 type GetUsersGetReviewParams struct {
-	// UserId Идентификатор пользователя
-	UserId UserIdQuery `form:"user_id" json:"user_id"`
+	// UserId Идентификатор пользователя; по умолчанию — пользователь токена
+	UserId *string `form:"user_id,omitempty" json:"user_id,omitempty"`
 }
 
 // PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
@@ -141,65 +488,219 @@ type PostUsersSetIsActiveJSONBody struct {
 	UserId   string `json:"user_id"`
 }
 
+// PostUsersSetIsActiveParams defines parameters for PostUsersSetIsActive.
+type PostUsersSetIsActiveParams struct {
+	// IfMatch ETag ресурса из предыдущего ответа; при несовпадении версии запрос отклоняется с кодом 412
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PostWebhooksAddJSONBody defines parameters for PostWebhooksAdd.
+type PostWebhooksAddJSONBody struct {
+	EventTypes *[]string `json:"event_types,omitempty"`
+	Secret     *string   `json:"secret,omitempty"`
+	Url        string    `json:"url"`
+}
+
+// PostWebhooksDeleteJSONBody defines parameters for PostWebhooksDelete.
+type PostWebhooksDeleteJSONBody struct {
+	WebhookId string `json:"webhook_id"`
+}
+
+// GetWebhooksDeliveriesParams defines parameters for GetWebhooksDeliveries.
+type GetWebhooksDeliveriesParams struct {
+	// WebhookId Идентификатор подписки
+	WebhookId WebhookIdQuery                     `form:"webhook_id" json:"webhook_id"`
+	Status    *GetWebhooksDeliveriesParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetWebhooksDeliveriesParamsStatus defines parameters for GetWebhooksDeliveries.
+type GetWebhooksDeliveriesParamsStatus string
+
+// PostAdminTokensCreateJSONRequestBody defines body for PostAdminTokensCreate for application/json ContentType.
+type PostAdminTokensCreateJSONRequestBody PostAdminTokensCreateJSONBody
+
+// PostAdminTokensRevokeJSONRequestBody defines body for PostAdminTokensRevoke for application/json ContentType.
+type PostAdminTokensRevokeJSONRequestBody PostAdminTokensRevokeJSONBody
+
+// PostPullRequestCloseJSONRequestBody defines body for PostPullRequestClose for application/json ContentType.
+type PostPullRequestCloseJSONRequestBody PostPullRequestCloseJSONBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
 
 // PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
 type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
 
+// PostPullRequestReadyJSONRequestBody defines body for PostPullRequestReady for application/json ContentType.
+type PostPullRequestReadyJSONRequestBody PostPullRequestReadyJSONBody
+
 // PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
 type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
 
+// PostPullRequestReopenJSONRequestBody defines body for PostPullRequestReopen for application/json ContentType.
+type PostPullRequestReopenJSONRequestBody PostPullRequestReopenJSONBody
+
+// PostPullRequestReviewJSONRequestBody defines body for PostPullRequestReview for application/json ContentType.
+type PostPullRequestReviewJSONRequestBody PostPullRequestReviewJSONBody
+
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
 type PostTeamAddJSONRequestBody = Team
 
+// PostTeamSettingsSetJSONRequestBody defines body for PostTeamSettingsSet for application/json ContentType.
+type PostTeamSettingsSetJSONRequestBody = TeamSettings
+
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
 
+// PostWebhooksAddJSONRequestBody defines body for PostWebhooksAdd for application/json ContentType.
+type PostWebhooksAddJSONRequestBody PostWebhooksAddJSONBody
+
+// PostWebhooksDeleteJSONRequestBody defines body for PostWebhooksDelete for application/json ContentType.
+type PostWebhooksDeleteJSONRequestBody PostWebhooksDeleteJSONBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
-	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+	// Выпустить токен доступа
+	// (POST /admin/tokens/create)
+	PostAdminTokensCreate(w http.ResponseWriter, r *http.Request)
+	// Получить список токенов
+	// (GET /admin/tokens/list)
+	GetAdminTokensList(w http.ResponseWriter, r *http.Request)
+	// Отозвать токен
+	// (POST /admin/tokens/revoke)
+	PostAdminTokensRevoke(w http.ResponseWriter, r *http.Request)
+	// Закрыть PR без слияния (идемпотентная операция)
+	// (POST /pullRequest/close)
+	PostPullRequestClose(w http.ResponseWriter, r *http.Request, params PostPullRequestCloseParams)
+	// Создать PR и автоматически назначить ревьюверов из команды автора (по умолчанию до 2)
 	// (POST /pullRequest/create)
 	PostPullRequestCreate(w http.ResponseWriter, r *http.Request)
+	// Получить PR
+	// (GET /pullRequest/get)
+	GetPullRequestGet(w http.ResponseWriter, r *http.Request, params GetPullRequestGetParams)
+	// Получить историю изменений PR
+	// (GET /pullRequest/history)
+	GetPullRequestHistory(w http.ResponseWriter, r *http.Request, params GetPullRequestHistoryParams)
 	// Пометить PR как MERGED (идемпотентная операция)
 	// (POST /pullRequest/merge)
-	PostPullRequestMerge(w http.ResponseWriter, r *http.Request)
+	PostPullRequestMerge(w http.ResponseWriter, r *http.Request, params PostPullRequestMergeParams)
+	// Перевести PR из DRAFT в OPEN и назначить ревьюверов
+	// (POST /pullRequest/ready)
+	PostPullRequestReady(w http.ResponseWriter, r *http.Request, params PostPullRequestReadyParams)
 	// Переназначить конкретного ревьювера на другого из его команды
 	// (POST /pullRequest/reassign)
-	PostPullRequestReassign(w http.ResponseWriter, r *http.Request)
+	PostPullRequestReassign(w http.ResponseWriter, r *http.Request, params PostPullRequestReassignParams)
+	// Переоткрыть закрытый PR (идемпотентная операция)
+	// (POST /pullRequest/reopen)
+	PostPullRequestReopen(w http.ResponseWriter, r *http.Request, params PostPullRequestReopenParams)
+	// Зафиксировать решение ревьювера по PR
+	// (POST /pullRequest/review)
+	PostPullRequestReview(w http.ResponseWriter, r *http.Request, params PostPullRequestReviewParams)
 	// Создать команду с участниками (создаёт/обновляет пользователей)
 	// (POST /team/add)
 	PostTeamAdd(w http.ResponseWriter, r *http.Request)
 	// Получить команду с участниками
 	// (GET /team/get)
 	GetTeamGet(w http.ResponseWriter, r *http.Request, params GetTeamGetParams)
+	// Получить настройки назначения ревьюверов команды
+	// (GET /team/settings/get)
+	GetTeamSettingsGet(w http.ResponseWriter, r *http.Request, params GetTeamSettingsGetParams)
+	// Задать настройки назначения ревьюверов команды
+	// (POST /team/settings/set)
+	PostTeamSettingsSet(w http.ResponseWriter, r *http.Request)
 	// Получить PR'ы, где пользователь назначен ревьювером
 	// (GET /users/getReview)
 	GetUsersGetReview(w http.ResponseWriter, r *http.Request, params GetUsersGetReviewParams)
 	// Установить флаг активности пользователя
 	// (POST /users/setIsActive)
-	PostUsersSetIsActive(w http.ResponseWriter, r *http.Request)
+	PostUsersSetIsActive(w http.ResponseWriter, r *http.Request, params PostUsersSetIsActiveParams)
+	// Подписать HTTP-эндпоинт на события
+	// (POST /webhooks/add)
+	PostWebhooksAdd(w http.ResponseWriter, r *http.Request)
+	// Удалить подписку
+	// (POST /webhooks/delete)
+	PostWebhooksDelete(w http.ResponseWriter, r *http.Request)
+	// Получить последние доставки подписки
+	// (GET /webhooks/deliveries)
+	GetWebhooksDeliveries(w http.ResponseWriter, r *http.Request, params GetWebhooksDeliveriesParams)
+	// Получить список подписок
+	// (GET /webhooks/list)
+	GetWebhooksList(w http.ResponseWriter, r *http.Request)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
-// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+// Выпустить токен доступа
+// (POST /admin/tokens/create)
+func (_ Unimplemented) PostAdminTokensCreate(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить список токенов
+// (GET /admin/tokens/list)
+func (_ Unimplemented) GetAdminTokensList(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отозвать токен
+// (POST /admin/tokens/revoke)
+func (_ Unimplemented) PostAdminTokensRevoke(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Закрыть PR без слияния (идемпотентная операция)
+// (POST /pullRequest/close)
+func (_ Unimplemented) PostPullRequestClose(w http.ResponseWriter, r *http.Request, params PostPullRequestCloseParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать PR и автоматически назначить ревьюверов из команды автора (по умолчанию до 2)
 // (POST /pullRequest/create)
 func (_ Unimplemented) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Получить PR
+// (GET /pullRequest/get)
+func (_ Unimplemented) GetPullRequestGet(w http.ResponseWriter, r *http.Request, params GetPullRequestGetParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить историю изменений PR
+// (GET /pullRequest/history)
+func (_ Unimplemented) GetPullRequestHistory(w http.ResponseWriter, r *http.Request, params GetPullRequestHistoryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Пометить PR как MERGED (идемпотентная операция)
 // (POST /pullRequest/merge)
-func (_ Unimplemented) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) PostPullRequestMerge(w http.ResponseWriter, r *http.Request, params PostPullRequestMergeParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Перевести PR из DRAFT в OPEN и назначить ревьюверов
+// (POST /pullRequest/ready)
+func (_ Unimplemented) PostPullRequestReady(w http.ResponseWriter, r *http.Request, params PostPullRequestReadyParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // Переназначить конкретного ревьювера на другого из его команды
 // (POST /pullRequest/reassign)
-func (_ Unimplemented) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) PostPullRequestReassign(w http.ResponseWriter, r *http.Request, params PostPullRequestReassignParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Переоткрыть закрытый PR (идемпотентная операция)
+// (POST /pullRequest/reopen)
+func (_ Unimplemented) PostPullRequestReopen(w http.ResponseWriter, r *http.Request, params PostPullRequestReopenParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Зафиксировать решение ревьювера по PR
+// (POST /pullRequest/review)
+func (_ Unimplemented) PostPullRequestReview(w http.ResponseWriter, r *http.Request, params PostPullRequestReviewParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -215,6 +716,18 @@ func (_ Unimplemented) GetTeamGet(w http.ResponseWriter, r *http.Request, params
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Получить настройки назначения ревьюверов команды
+// (GET /team/settings/get)
+func (_ Unimplemented) GetTeamSettingsGet(w http.ResponseWriter, r *http.Request, params GetTeamSettingsGetParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Задать настройки назначения ревьюверов команды
+// (POST /team/settings/set)
+func (_ Unimplemented) PostTeamSettingsSet(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Получить PR'ы, где пользователь назначен ревьювером
 // (GET /users/getReview)
 func (_ Unimplemented) GetUsersGetReview(w http.ResponseWriter, r *http.Request, params GetUsersGetReviewParams) {
@@ -223,7 +736,31 @@ func (_ Unimplemented) GetUsersGetReview(w http.ResponseWriter, r *http.Request,
 
 // Установить флаг активности пользователя
 // (POST /users/setIsActive)
-func (_ Unimplemented) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request, params PostUsersSetIsActiveParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Подписать HTTP-эндпоинт на события
+// (POST /webhooks/add)
+func (_ Unimplemented) PostWebhooksAdd(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить подписку
+// (POST /webhooks/delete)
+func (_ Unimplemented) PostWebhooksDelete(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить последние доставки подписки
+// (GET /webhooks/deliveries)
+func (_ Unimplemented) GetWebhooksDeliveries(w http.ResponseWriter, r *http.Request, params GetWebhooksDeliveriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить список подписок
+// (GET /webhooks/list)
+func (_ Unimplemented) GetWebhooksList(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -236,11 +773,17 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// PostPullRequestCreate operation middleware
-func (siw *ServerInterfaceWrapper) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
+// PostAdminTokensCreate operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminTokensCreate(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN"})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostPullRequestCreate(w, r)
+		siw.Handler.PostAdminTokensCreate(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -250,11 +793,17 @@ func (siw *ServerInterfaceWrapper) PostPullRequestCreate(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// PostPullRequestMerge operation middleware
-func (siw *ServerInterfaceWrapper) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
+// GetAdminTokensList operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminTokensList(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN"})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostPullRequestMerge(w, r)
+		siw.Handler.GetAdminTokensList(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -264,11 +813,17 @@ func (siw *ServerInterfaceWrapper) PostPullRequestMerge(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// PostPullRequestReassign operation middleware
-func (siw *ServerInterfaceWrapper) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
+// PostAdminTokensRevoke operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminTokensRevoke(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN"})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostPullRequestReassign(w, r)
+		siw.Handler.PostAdminTokensRevoke(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -278,11 +833,43 @@ func (siw *ServerInterfaceWrapper) PostPullRequestReassign(w http.ResponseWriter
 	handler.ServeHTTP(w, r)
 }
 
-// PostTeamAdd operation middleware
-func (siw *ServerInterfaceWrapper) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
+// PostPullRequestClose operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestClose(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN", "USER"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestCloseParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostTeamAdd(w, r)
+		siw.Handler.PostPullRequestClose(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -292,31 +879,57 @@ func (siw *ServerInterfaceWrapper) PostTeamAdd(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetTeamGet operation middleware
-func (siw *ServerInterfaceWrapper) GetTeamGet(w http.ResponseWriter, r *http.Request) {
+// PostPullRequestCreate operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN", "USER"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestCreate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPullRequestGet operation middleware
+func (siw *ServerInterfaceWrapper) GetPullRequestGet(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN", "USER"})
+
+	r = r.WithContext(ctx)
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetTeamGetParams
+	var params GetPullRequestGetParams
 
-	// ------------- Required query parameter "team_name" -------------
+	// ------------- Required query parameter "pull_request_id" -------------
 
-	if paramValue := r.URL.Query().Get("team_name"); paramValue != "" {
+	if paramValue := r.URL.Query().Get("pull_request_id"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "team_name"})
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "pull_request_id"})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "team_name", r.URL.Query(), &params.TeamName)
+	err = runtime.BindQueryParameter("form", true, true, "pull_request_id", r.URL.Query(), &params.PullRequestId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pull_request_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTeamGet(w, r, params)
+		siw.Handler.GetPullRequestGet(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -326,31 +939,37 @@ func (siw *ServerInterfaceWrapper) GetTeamGet(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetUsersGetReview operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersGetReview(w http.ResponseWriter, r *http.Request) {
+// GetPullRequestHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetPullRequestHistory(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN", "USER"})
+
+	r = r.WithContext(ctx)
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersGetReviewParams
+	var params GetPullRequestHistoryParams
 
-	// ------------- Required query parameter "user_id" -------------
+	// ------------- Required query parameter "pull_request_id" -------------
 
-	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+	if paramValue := r.URL.Query().Get("pull_request_id"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "pull_request_id"})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
+	err = runtime.BindQueryParameter("form", true, true, "pull_request_id", r.URL.Query(), &params.PullRequestId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pull_request_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUsersGetReview(w, r, params)
+		siw.Handler.GetPullRequestHistory(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -360,14 +979,537 @@ func (siw *ServerInterfaceWrapper) GetUsersGetReview(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// PostUsersSetIsActive operation middleware
-func (siw *ServerInterfaceWrapper) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
+// PostPullRequestMerge operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostUsersSetIsActive(w, r)
-	}))
+	var err error
 
-	for _, middleware := range siw.HandlerMiddlewares {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN", "USER"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestMergeParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestMerge(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestReady operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestReady(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN", "USER"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestReadyParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestReady(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestReassign operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN", "USER"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestReassignParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestReassign(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestReopen operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestReopen(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN", "USER"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestReopenParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestReopen(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestReview operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN", "USER"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestReviewParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestReview(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostTeamAdd operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTeamAdd(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTeamGet operation middleware
+func (siw *ServerInterfaceWrapper) GetTeamGet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN", "USER"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamGetParams
+
+	// ------------- Required query parameter "team_name" -------------
+
+	if paramValue := r.URL.Query().Get("team_name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "team_name"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTeamGet(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTeamSettingsGet operation middleware
+func (siw *ServerInterfaceWrapper) GetTeamSettingsGet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN", "USER"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamSettingsGetParams
+
+	// ------------- Required query parameter "team_name" -------------
+
+	if paramValue := r.URL.Query().Get("team_name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "team_name"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTeamSettingsGet(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostTeamSettingsSet operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamSettingsSet(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTeamSettingsSet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUsersGetReview operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersGetReview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN", "USER"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersGetReviewParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUsersGetReview(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostUsersSetIsActive operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostUsersSetIsActiveParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersSetIsActive(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostWebhooksAdd operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooksAdd(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostWebhooksAdd(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostWebhooksDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooksDelete(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostWebhooksDelete(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebhooksDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooksDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhooksDeliveriesParams
+
+	// ------------- Required query parameter "webhook_id" -------------
+
+	if paramValue := r.URL.Query().Get("webhook_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "webhook_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "webhook_id", r.URL.Query(), &params.WebhookId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhooksDeliveries(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebhooksList operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooksList(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"ADMIN"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhooksList(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
 		handler = middleware(handler)
 	}
 
@@ -487,27 +1629,72 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/tokens/create", wrapper.PostAdminTokensCreate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/tokens/list", wrapper.GetAdminTokensList)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/tokens/revoke", wrapper.PostAdminTokensRevoke)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/close", wrapper.PostPullRequestClose)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/pullRequest/create", wrapper.PostPullRequestCreate)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pullRequest/get", wrapper.GetPullRequestGet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pullRequest/history", wrapper.GetPullRequestHistory)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/pullRequest/merge", wrapper.PostPullRequestMerge)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/ready", wrapper.PostPullRequestReady)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/pullRequest/reassign", wrapper.PostPullRequestReassign)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/reopen", wrapper.PostPullRequestReopen)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/review", wrapper.PostPullRequestReview)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/team/add", wrapper.PostTeamAdd)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/team/get", wrapper.GetTeamGet)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/team/settings/get", wrapper.GetTeamSettingsGet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/team/settings/set", wrapper.PostTeamSettingsSet)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users/getReview", wrapper.GetUsersGetReview)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/users/setIsActive", wrapper.PostUsersSetIsActive)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/webhooks/add", wrapper.PostWebhooksAdd)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/webhooks/delete", wrapper.PostWebhooksDelete)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/webhooks/deliveries", wrapper.GetWebhooksDeliveries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/webhooks/list", wrapper.GetWebhooksList)
+	})
 
 	return r
 }
@@ -515,42 +1702,113 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xabW8bxxH+K4ttgTgALVK0XaD8xtiKqg+WWYoGigqCsOKtpEvuzXd7agSDgF7Suq2M",
-	"qvlWBEiMwH+ApsWK1gv1F2b/UTG7R94deTxRpiwX+WJTx73d2dlnnnlmli9p07U91+GOCGjlJfWYz2wu",
-	"uK/+anBmLzOb/zHk/i4+MHjQ9E1PmK5DKxTewiX04AzacC5fwyX0oUugBxfymMAZ9OEC2nAJJ/KIFqiJ",
-	"b7xQExWow2xOK1RwZq+rzwXq8xeh6XODVoQf8gINmtvcZrio2PVwcCB809mirVaBPg+4v2RMsuo/cAJd",
-	"uJQH0JPfa/vkAfTlHoEr6CtTT6EPHfW4C+fyeIJ5YcD9ddO4kXGtwZfKgQu+7/p1HniuE3B8wL9jtmfp",
-	"j/gdfmi6Bk6x/Kyx/vWz58tPaIHaPAjYFj71eeCGfpMTxxVk0w0dQ3nA812P+8LkQWqq9GM98UvKndCm",
-	"lVXaWKg+XV/409JKY4UWaK2e+vx0ob64gGujHdWVlaXF5ejP9cfV5SdLT6qNhehbbeVaYXTzCbuzTi12",
-	"4qo2LR4fz+VufMObYmy83uH4sAKthZZV5y9CHohxD7AgMLccbqz7fMfkf4lgncZLdMoELqENp/ivfIX4",
-	"gUt5JP9K5B50oSNfy39BB7pyD5FD7pXm5spfImwEt4OM7Q4NZb7PdvFvFoptV8Epa3TT50xwo6r2sOn6",
-	"NhO0Qg0m+H1hqvhwQstiGwgdDcEM3/tbs83ghZa17mtfTjI0NUbHScaoQDARBknsPastLNMCjVA2jp2R",
-	"8x41JWvhpE+HSxayzvwa3Kxsu34WeHJP7NfgrCy/IOeP+8Lm9kYUPUPI/9bnm7RCf1OMU0gx4r4izvJU",
-	"vZMVCzHvX0sUyRQxMGKS2dGCY8abwTprCnMnudyG61qcOfjqgOazzga/m87QOFkM3ykkVs6yGdPYja3N",
-	"890n3UvyJPL2hZOZzqarljEF8g2t1Uk9ikZSVfFpc0eQFe7vmE1O7jV4IEiDBd8WyNfMski5VH6E9LrD",
-	"/UCz9Pxcaa6Eu3A97jDPpBX6YK409wDBzsS28lzRi2O6qBlVudfVqQGdzJD0lww0yQ1EggMe6+HaDzwQ",
-	"X7nGrs6hjuCOep95nmU21QzFbwK0KpXPE3RBw3mawRDU8+/Pl0rzmQFaoVXDIAFnfnObtpIS43Ow0owM",
-	"k42KtIpSD7QyUhsrl+Zv5nDPn5TiV2lYRvA+QENu81xistYc3co5KG1eHkkmpUurlemytFSp1Ynchz6c",
-	"wgkqazzMh6WHU3gttjHPnrRazVgf/g0dLaWLSX0PbRRPXa2gPkTq+0hb9/ubnemoKE6K1FgU1+rENAiz",
-	"fM6MXcK/MwMRjJzFTPtEPx/Cf6FL5L48lP+ArtyXB9CRh9CVB3ql0LYZlh8UfhmciDyQr0mtTqBHoK09",
-	"hS5ShcgrnAPO8Kukzuypd+AE+qScLTWhB6cj1dRwdrkHbWRmtqVAn8BTQNfQyhQjKoE4NSE+VaNn4MPJ",
-	"YZYXNNfy1zXM9HHMU7ob5oklOsUMd3++dL/8sDFfrjx4WHn0uz/fGjdFwvHu2Qk6iqBUtPTlsWoP9MjA",
-	"nDtmK7RnlJZGY/eNiquuCtFB9J5BG84io8k96Kk3L+AK+qpfcCkPVPAeE+jDlYrTtvwb9OTxl9PHos81",
-	"eqYOx/rghRki0rVirEaoLOdiLgc/OFee2Jw5kAupJT5/WKPSDB99ckGBe/As1uTG+gYiNHxEby+KRybP",
-	"6YT0oQN9eA/98aSkMk7+Ufo0vdLaFOwBb9Ts3bE2TA9DuCOPdPMOIxrt+yxs0oNzTODZXcTXWWxzQwkU",
-	"1dqYJZTpMVH9pNeAU+SdC0VDx1o7IC/twzl0ybCFt8OscJKcGg6K5VSTOY4ryICTiOsQbQOp1bUrHPcx",
-	"cwzTiCqqtF3yQAkYJH15CFdR30zRKIrDnpZG6Ks800b6jLF1jkt0rUkiSKnSsTmwh5gOwdJ0YKioRvE7",
-	"Yuib3EN7J4/gfKwFmKXILvI3keqdJtu4UfVrBqqTOyAZIlwits0g8vTtSVj4CdpyTx7Kv8dBdKKT3bC1",
-	"CVcYztBBXJMolWXEnzwez5rjQyMli0L1Es7wa5UnJ5GI8jWBE7QRh6hhWut29efRC4SczIrnX2SGkZ9N",
-	"G5zZVcOYJYMO22CrqT6N7qcO06HOCnG7hVYts8lpq5D/Ujn90lfuBm2tpRo+1GO7trqqmRoojWFo3HL1",
-	"LaI+4ed2yQZrfsujW5FJaXJg6xSOmiZT/ZgqfZMVObQ15Zdmq3rTFzUxiwz3/Qlr39HdfWwdnIrfQyL3",
-	"iTyUr6CtZhhcG15Aj9yLHSh/kAdF6MO7SIGcy2OdXjIzLnThQ1Jy4wmmGGGLK+dH/6UJYZErPljkQnUQ",
-	"41vP1Wz/xUOK6VtRxONssvP/JoJuzikj0PkZ3sl/QhfO8MzS/H3nraof8/tTGKnjpeC5wmjvRgCegEB0",
-	"eoAQ1I3vPCA+x6GLw5E3xWPyNnx2NCYLFr38J6131kbQOmVvaPqbqLF7voz7qMmF7MSLkbQxUxU4v8AV",
-	"9BTZnZFa/Qvd3Zv0i4RrwFmrfyGPCgTeI5pzK5KpBO0AwAqJKQAHXCwF1eF91GR1pV5dSYyeQWYlCG2T",
-	"WQGfHiMffdU38aCvu+q65R5EGN0Jjrsgi7KvpfocVw1WygsedUM5nSj6OZG1f9AlBnyYiMy7zwdvpi/a",
-	"06H3VhF+O9qcDj/5PZxDG94TaGOygx508Hs1spf3M6OxQGsNn70c/OxIZxFM7NEDPTjxIFUCJZ7/gTNL",
-	"bNPWWut/AQAA//80B9xs2CUAAA==",
+	"H4sIAAAAAAAC/+x9a3PbxrnwX9nB+87UmYF1c9KZKp8Yi7F1jnUpRTttHQ8NEWsJDQmwAKhEJ6MZW6rr",
+	"9CiNTjud6ZnOpK3bD+crLYsRdfVfWPyF80vOPM8ugAWwIEGJtmyHnySSuDz77HO/7dda3Wm2HJvavqfN",
+	"fq2tU8OkLv5brhpr8NekXt21Wr7l2Nqsxv7EusHj4AnrBXskeMy6wZNgB7/oELZP2DHrsP1gN3gG/wVP",
+	"dcLOWIe9Ch6zHjuFW8nn2o3PNU3XvPo6bRrwBn+zRbVZzfNdy17TtrZ0rUJ9d7P0yKeuAoL/gcewLjsk",
+	"wRN2zM7ZSfAt/IWPXXYc7LAzdkDYK3bO9oNtdg7vDraDbwk7FKCcB09UAFi2T9eoq20BCC3DNZrUF8iY",
+	"f7Rg+PX1LDSApQweeuyQ4Iu67CDYZQfBTvB71mUv2Tlh58E222fdYJt1PuYX9QBH3eAJwMtesQ47YF12",
+	"xnrww36IbdZLgM+fc8xO2Dk7C/bgecET2JEnBFFywM7ZKflwekbTNQvg5Bur6ZptNGGt84+u8xX134jl",
+	"dqNRob9pU8+fN3/epu6mYkP+m0McbLNe8FvWw51HvJPlSvj+3+C90etb7Uaj5vIH1yxT0zX4YLnU1GZ9",
+	"t01lqJqWfYfaa/66NjutK2CsUqO5aDRpHnj/QmQCYQKhnLFz1oUtOg32OK5OWQcoJtjNgdWnRrOG/18G",
+	"ys/o6rrjfHERLCIpH7BXrIcE38uB80v+hsuhcwtu9VqO7VEk/E8dd9UyTWrDh7pj+9T24V+j1WpYdQOA",
+	"n/y15+DP9Cuj2WpQ/Nd1HZffYsLzP12qfDI/N1de1HStST3PWEPMrlsecVrUxecQAbNH/HVKSnML84vE",
+	"dRoU0RfD//9d+kib1f7fZCy5Jvmv3mQZXlsR8PPVpLD8Dy4vCKCWHSOjdZD/OJYP2T5egBxFQJLh1yfs",
+	"LJQh5+wVyp9O8DvWC77TtnRthbobVp3etY0Nw2oYqw16OWQt3StX7iyV5spzCWx5/DUEcLZB3YZjmNTU",
+	"iQuykjQMn7ojxdRzXOY+EB0Ra+6yl8HjYIf9AHjTAT1PWJeg5OqyA9YDPCKmuCB6wTrskJ2zIy66zoK9",
+	"YBu5TNIyKOmvR6JeBbK4elJSCghv1XEWDHtTiCfvcjivlKrl2p35hflqCuuu4VPSsJqWT+hXdUpNao4U",
+	"zX9lJ6zHGT/UGaA/QeSfEPztFEgvIf1BUwDa9xHxR0Rsyyv2KtglIM+Cx8E38BVS+f7IMH7XNtr+uuNa",
+	"/0FNjruLovvuYulu9fZSZf5XKXQ3Lc+z7DXiuMSyN4yGZZJVarjUJb7zBbVHivp/hiIgEgCcyA+AWEFF",
+	"nAjdjJL5KHiCqrsXbLMuO4H7koj97LPPrpfa/jq1fcAErjtftwI892B9iLNyjJ+LovRe6c78XKk6v7RY",
+	"K1cqS5UkFXMmIauOuUnWDY8YNsEHzBLToZ79E580wRwgHOBZ0nJBLvub5HONfuW7xucayJ227bVbLcf1",
+	"R8wEf5Etm7NQpkSmEsd8sAP/w0+wUb+T1OTvwD6SAMLtKLWsKpLM7NeaWI3FdVrdpYZPzZqBaH7kuE34",
+	"TzMNn173LdTzqd0KdezX2R9cR2yH3W5qs/c11Fuart1dKVe0B4on+SFQGXnbYaeSXgL5yjUSapvfs05s",
+	"5W1LVq+wIRFjh5x2AR0fwzexBA+eBo/5TyBLTlgv+Aa0GTdKg6fBH4JvtDxgwaJQLb3tUVf8llrL3wV8",
+	"h2jTdjjHBN/qaLYS7goALGiACRv9HEGRWS3c8AglH4P6fRHsscPwmZx7D0BjE3wGsCyiXmWDxUbR/Xhl",
+	"Ym/FTuoydcTb56z+mtZ9WHSSmPuz5OJStfbp0t1FEHEm9Q2rgfSXNn5ntZZ7fXpqalrbknkWLiMh49qO",
+	"Tx45bZvzXZKeo9emyNwxE5RZLZcWauVfzK9UVzRdW64k/l8oV26hKAaYSysr87cWxcfazdLiHEiWsqYn",
+	"VjS/iCKntlItVe+Kx8DvS8to5uGDlpcrS/fwQfNz5YXlpWp58eYva/9e/mWtUr67gj9Uyj+/W16p1uYX",
+	"a8uVpVuV8go86165sgKibGF+ZaFUvXkbvsoKONjp2u3SCr6ztlyBO1OqRbY8U0o+YWfNL1bLlcXSHSXP",
+	"1h3XpQ0UxWqC72O8y3q7Q649/MV1YbFcn597+EFIvGhmcvueO9InyBCd4OnHCcUU/FHIgPC+fXYGeh5/",
+	"P0P+fgpc9g3rsRfANyqulmjRME0LFmE0lhPEo7gnKa3Qpz2DVcGidcKOJWYOdtkpd1HxCtYLgQ4BO2Yd",
+	"RWjgYYozHmoKBowY5OsBHI70H1+fZebU9ZyNVDz/qdForBr1Lyp0w6JfUgWvxT5iljb+AbEKIYrPWCfl",
+	"diIiMGAgi8Ij2NhDMJiJMAm/Db7j0QBN7yuJ++MkvFCXIFateNktbwgz5PK6UyK3IVGTWTxgC74LvhEx",
+	"kq50SWSyIU0Fz9Ah6YAeBH23JzwT9gJeSkSQ5IVgnB47UoFOAQsCs9FiLdv/6Yeangkb6doj12nWPN/w",
+	"254seecqpU+rIHC4ZIxk7c07SyADVRLHaZg1V5CbWuQ8Dx0qdpRBE7kmxEOlfG++/Fm5UquUQ6H+gWqd",
+	"/V/1PTpysEHPuJRRvhQZ+pztK3+MQl2hLDtLPRMjXGL/OmHgLrHXwZ7aQhkNwvkX8SNuVsqlqlBQAoeS",
+	"WlTgNfq2tnL3k4X5Kr85ejXXkrWbt0uLt5QgpKVRSHniwoGGiRSqy7Kt4XnWmk1jmlJwo5ANJLM1Z8Fu",
+	"8DTLiuCEXgO9RYIddooW3zNhYn5HpiYmZnSu/BJaC576BNXVOTvCoNwp6yW4PtgFCrV82lQrIvGF4brG",
+	"JnzmLmmejSqQVsoXV3a7IWI2PFKWecQjIf374Y79I4mcYFePJHiwywOOhxyFkbxDrMorl5fdz5/KqCMF",
+	"VprUXaM1iBO5lklrLjWEB5m21IuISnCBQVQ+RaHZY6fCWIf78JaOWpwORC1CeanNydjSXw+4Jt+RQ2x6",
+	"am8GVwkGWKxzYrE0DMcU3WO+t6qdHYm026CuZzn2gPzOckUH3t5HT4tTyb7khoYiHbj4B5FyADoX3p2Q",
+	"6po+WHOmZF82O5DdQpnxI6ToKkEXr3aA2FxZd1yV7OwrYEZHgKPe2DeI9X4IFpScQatJ65Y5tCVZt8Ll",
+	"RQGX2MfkynWlJlzKHBSlbJ3+ali+WHq/LoOvWjUkpfL9q0dGw6NpN75Jm6tCuRQSEfCKBbxHJSYSHsmg",
+	"5JS84vhGPQIpb4Xi9cOt0/JqRt23NmQuWHWcBjXslC/TF2x+ZQ435bo90T26BEfe8lao71v2mjfkAo1G",
+	"w/myVncdz6v5gg4y5kKHHUpa5Jxwyxd1KOthxA/STUqji7uLBxjzf8l6WSsii9TIhgF4VDrur7IBlvFI",
+	"uanyCqON+HmPHUCmPQnwd7GRB6qShwbg69+nHLXQRiLXhApJY2w4C7Bp2TWj1XKdDUPpZT5PVAlkLRUC",
+	"IRT2A98GEUtMGkLLlT6Wri7SBy/4S2BHSaR9ai3q1louLKhpfGU1QWZNTyHM/MOUyo9M3z5wUWo6kc0S",
+	"Tk/wgWflh4DGow1ax9iX50M6bE2Vvn6ORj0Py77kVhF4gi8woNFRAvgxwbKHMMsSeQsAJJAgRvpFIJnH",
+	"gkWNQ/ZV6r3R9EhLuIZtOk2M8rZts+Y6q5at6VqDGp5f46lUTde+pNbauk9N7UEB03MkEjaz03pWfKjE",
+	"011PFYYaIFoTEA8RROoraosak/FeJpICe1djYio1grwxMSr7mzeirmOONqwNUdiR0ga+T5st31MVGoEZ",
+	"gfcNbQfxtxWPTA0ZyOKXhzGRzPsbwDVR2iHzs02/8mti3UOtK2sCL5cX5+YXb2m6Nle+M3+vXEFDb65c",
+	"KhBCkbGkywEVaXWy6xBuVJ9tXmmvSgQ+iuBoDItKdf2T9TCpj9m9F8EuxNrZEbmWDp3rRLbVJ7hfrROg",
+	"7QmTIiEDZB9gydkOKmYIMqOE7eHDj8n/Pv5zoqRDvC7YG0oZe7TuUl9pYZwE3wXPktVMPXJ7oXTz+srt",
+	"0sxHP71kxlMZGHcbSqilWqmBxqN0LX9gctMGBOY4Stqu5W+ugOHOt5nXNEC1gHLTo7oEMMMeThpm07In",
+	"MWPpTfK3PQyDpf/2WZUbZWhssX00tiCJFOxENti50LmRvbPPde1LQCr+esy65GFJFHdgnmuWfIIwks/b",
+	"U1M36vhy/Jc+nCDsuUQ3UIvC808vuGQPo7kYPcKCqU7wJM4VpWqoWA8pL8ze6tk0Eq/REovBOpcz1pn4",
+	"3A5rF1HLIbAxCaz7fouXGlj2Iwc32fJBmWvLFRIGzkgJowVNavtEFHGRa1VIt1YN7wudQKCNzEzNfPSB",
+	"pARmtemJqYkpDNK3qG20LG1WuzExNXFD07WW4a/jBqv2DL5vOTw8G1W9zZsAk+P5JbgBKxa8m/xyPSzb",
+	"+MQxN4crDuG6WjMasKSG0fKdVpjhntVEfjzS91obM89xMccQvk4h++cCVRJFvcAUr8qpfAUvbqULI9Pl",
+	"jjNT0wUQHWMqlQUMSzv6ue1RXYqyIiEH6nwJgSWKosAYCiSSOZUueYiPfZh0T4TDgiICCx1f4QM7vE6P",
+	"ncMOfDg1lbeUCGWT6QomvG968H2JSjK86cbgm+JaVLzjw6F26nIFSjkFLQKRoIyPeAUAgjbzs8GLSVcv",
+	"bunaR0WQoCg2lXWMNns/qV1CXnuw9UDXvHazaYCRqrE/CbpBkcxjDdsxVcnCtqPpmm9AFOS+hkJKewBv",
+	"TEq4hsXl2hpViLdbVJZud+DSDN9NXZbvigfOYg5Mmy8qjvSGZMlgV2i+NC8evUnueBdIkDPVTvAsJEDZ",
+	"Go2pUaRNBpKgSzecL4or2Qq/fFgleyEtKZfRDRUpCO+7mC6b6m9copWFJe8dIbjGIj+/OvhdFPJ/i3c4",
+	"JeJzGKoVZ8Mm6w3HG8BOUvLsJl6d7Ju6r15nfMlk2Fe19YBT84WM3b71lBfiV0VSbxi2Td9+Ce69oEZs",
+	"uYMYQNo6hH+ghluuEN51IHxKHhxnPSKykbqqebBfdwFes7U1ljsZNCvlzdTP3igQvM0nTIRsIwjTM29Q",
+	"/iZixxAHCVsWibBaOxhzOGGdKxPHsaCFBoZjzJOBmF2uhCZgKo90jfVwR08xYrMtSoZF5WOys2zvA0lE",
+	"S8zqqSR1gfCCLKovHV6QSiIgeKD3EcHK6getZJrEo4ZbX7+wjE6UZQwIP5iu8chXJtFEBDHatn0iaGsb",
+	"qIx1CdZgRNuprujJZlqGVSA5NSKXUTqDyjdeX3hEVs1uXvXhfa0NzcntG9oDGarL01OcRQjrZqLI3XSy",
+	"Z+lNqEw5SD1WkSNSDf8V1gdPpsrFM5oz2C2uO/s08sjNMnGDznKFWCYxGi41zE1Cv7JQp4yuLU7WwjzC",
+	"J/dFvQVaLys+4+Jt2BUMLT1jXd62nqxH6A2qb0mUBEs14dBEk1eLAXErMjOE5hThqryolXT3LeoP7d0o",
+	"pidwR+fttvPHcuo1m/JXy7XpsNtypTi/rFue77ibBXnmtrj66vhGlumQs+Wvl/P0GuT3rk9PXZ/5sDo9",
+	"Mzs1NTs19Su5XGB2OtFkEhoVgo/CNpEtfdjHzqQqUbk9JB6b7Tnp+4Ibs9PZF9xQdBHxl6S++kjxXqmv",
+	"BVBfMMSS6kvdCMcKFQrLhz1niqKCwUXWA41hAUyhWP5zufpBuAVy4SPrhpUKfLLMGc+wiyalsRR8F6Ug",
+	"JB6iaVHfZYvMjoaSk1iAIzvkKsfgJFnspqeqXMLaSCizPQvHR6EJCOCeYstOD02keK5R1JGjrnPtscNk",
+	"C1ZXtO3mWFo6n0Dyghf+StMxWEdU4UStQ6lWowlN7x+EWEAMvSfx4sF9Vs/ZSaRuL9dXhfWSYa5+P7EN",
+	"wY5WrEXq7QxmjyBuEDeUpRTwjQ9nP/rpr0YWWYhacaLYwo03H1tQh+Mj0MY2/JWH4wVFcxuIjzNaiUpc",
+	"Y2UUvQSH+vEwMtCW0WgrwxGZyRtxTKJu2DAqBPmAYAbPBMWFK7Mdv4RtEtRMvp99n2jYAGp6xrshVF2d",
+	"uWClJn7kTDIxHerhOJN1Y4MSajvttXUSt2/wyUgj3L28iC4vn+TJK0nNnRVHxTglMsjAOsVBl7HeA7V1",
+	"LCTU60qGYDSucC6kgleP09Y/vrQ1+PGEzxvLTreAoQTZ2SPw9Vixjk40Z8Z9vB2Zb5HyjuA7jJO7Y5k/",
+	"QOZzH3FfpCp6PCnADkOlu8/ZrngyYCi5j+5Cvtct1WjuJKfFpbsLzlOuOG8KhXEIkncsetFOVKICf0vP",
+	"tgv22H44W5Z3twmnXt0Z9+1AH7oSLvlK9BcEF6UeghlNfx2etfyS4ZLol1eAeuL1V+/4QodJ+6M3mDCf",
+	"wfW0GkadmrXVTR4sHp2fm3p4n4FE5xhvfanqdO4MHPWIXbXymwoFgf+eO6iqmxwMDV+ej82CkZsFr4bq",
+	"uRjaH+ehIoUjjO8E6YzhX5zg9K1osYMhQHGAJdcRludpZlzzUE8Rx+Zuuuyg3zRsE7aZZuEKtlNaKpwf",
+	"xYsfejwPL3RmHx89Mc8zhs52CO93JoJTsCuuHsJDLJtgM3oUSRAiKgVo/0aZF8EuO+F7J7GUUn0ODDTE",
+	"Y9fkMceisc/iIYZQjhLfIThsPsa0v9SidhL4otGCAVsvzUDtu/kiOuO4BMvTBGwjnKr8PQ4C35FmJfIO",
+	"emlEFUaPOzAjGPYldzZfsDc2fAsZvlmjFrMrZ+A/YCzkLFeNEdHmKsa68Mtw4aGZmD6sorBh7LRowiwe",
+	"YFTi5eOQyI8zJDI2ZN6zOv50NAOEk0g4hm6oWumNRX4hkS/lbMIzpsKPu1g18PoC3dFgvWJyHS+/Erke",
+	"z+2Tx/X181kzVUsXVQAjGRk4fDl/asjg5aIQOUMI3wtlBJNz5WneoJXCsyrGnvV7qJAyme4hHP6BTuNY",
+	"ZQ3oUuOnUyDAj0M0i9C7cqZ+7J1Ayf2gEjiIEEwaptlfKcFozZJpXqYBLZqVej8x9I5P6ksMtpFn12kl",
+	"mIeDpbT9bppJ3vSJs4raT5qep7WMTYiPeFphd70aBU9G3GIVThi9apTAeFFqp8+Fyh6TURBRRVTHX5Nn",
+	"RcjzwDrvRBnuJduSkif6SMdsKPclcfIiNZqvsXMpvTP5XUxRBm2HYDEulqaKsmrsBYoKXmU7GybqL1eu",
+	"TKIOO4Yh2SeViOPsQAlCZuX8VIRrMUXDAQqTOGqX50KiEyqVOhOO0JIdiSoO/ZVE9IC2J7j+Iv1OyZNY",
+	"L9+y8SMWbX9jL4L/xOOMt9OBv7Fd20/WZGzct6YYLtFtUFAG9GNiT0wnL8LN4STzt4GrPXmqemZUunDi",
+	"0wPL7z9QTcWeUQ+nTo91HpaXZQgH8XOI2YJ8/X3q/JvemLvfQ+4+y+6yIqeW0/2cl+bJFwAe9Qd7XCGh",
+	"rlD/UqM/MhzLNWqGYSNOwxGsrqEpOfhGDgcnx7QnXK+G4cNo5eFcL4lNX3OM7DUKj79J9t8fRaVqV0Ft",
+	"khB5Q1wKdRPH2CD5mBstubAlSub6nv2Xx+tjKfc2+zp/ESe+vRk5CIY7GkDxKUN5ZhCcmODdiq7MWEHF",
+	"T3TNOcjg47xjKPhk9bzopjTosoOyUpvVfoOmVnjqtHRYQUxv/TMKlzbS5CQEx88bm9HzIOWe9as3TEJZ",
+	"tNE8feSXouP8AieqJoEp2GgeDzxdrvyE030Oeb0LluHbNtjiJ3iW0Utg5b7phULlaKH8QUGSkD8e9ee9",
+	"UnTwSr4dhreuSFdfSTZWioYIb6vtjSDTOqJzvXL5q995Xa+hErstTtjJYkvlTg4KKSWKqguEkYxGY+lR",
+	"LkWEkgzoCWnBpC2X1g0/XL1qmi1Ekn7oywjwwwGvCezieVg93saAB3cAF8AB/OFBE6nD95/gw07Dxg/Q",
+	"gdHR4OmTnI7gleQhoOhhSBrabJGlXthMPspd9ji/fCUz8sdZ2svZ2f8SfMgJXTRQ/RaWwF4SrEHaxvEi",
+	"0rEreYaFWrWJY2+8wflccTaSd8mcbuIkpGQZzERUARNWdKfsLXHikfaAn9DDz3/xZicnVx1/Qrxjou40",
+	"J/mS+OO8C6u61KFNFzkdKe+ooqF0o9u4glNOBGEM4krViVnF5PffpQOijt/JxO67cvpCiGXurt+uVpev",
+	"B3/AIATICpgos03Cg8GTB4KFEiPk/bTQMGmDDhpJHN47x699IycxJA/+GobZpDtHdxpDhtR3kMxP5HDX",
+	"2MTIRde7HCj7l9jpXtRfFi8t2CnGYnC8oSDsvLCXxGTh1cM6neIR0ShGSKgrolTRQYrxbl/sFMfRjkhN",
+	"oqlQgCh9pqdCkV/4AEEJnEIhoj+zczI9NSU1ILIDDCs+JfGcGuyOPR6Li/dYXGRyjCl6YN0kPRyHFn+M",
+	"gF4BoTLoHK/wvtEf4hWCMCyXJg3MASd6RS95MLwh2pMOdehGDX3Qbzu2LIuf65WgSRBaapos8t7w+Mit",
+	"B9EzopMveZJoS4++4K6t9EWifFn6PoJC+o4fkSR9cZsaDR/CsFv/NwA65aPxQp8AAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
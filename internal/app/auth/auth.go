@@ -0,0 +1,94 @@
+// Package auth identifies the callers of the API. The auth middleware
+// authenticates the bearer token of a request and stores the resulting
+// Principal in the request context, where handlers find it with FromContext.
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"test/internal/domain/model"
+	"test/internal/domain/service"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// TokenID identifies the credential, for logs and audit.
+	TokenID string
+	Role    model.Role
+	// UserID is the user the caller acts for. It is always set for RoleUser.
+	UserID string
+}
+
+func (p *Principal) IsAdmin() bool {
+	return p.Role == model.RoleAdmin
+}
+
+// CanActAs reports whether the caller may act on behalf of userID: admins may
+// act for anyone, users only for themselves.
+func (p *Principal) CanActAs(userID string) bool {
+	return p.IsAdmin() || p.UserID == userID
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the caller of the request, or nil when authentication is
+// disabled.
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// Authenticator resolves a bearer token to its principal. It returns nil
+// without an error for a token it does not know.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+// Chain tries the authenticators in order and returns the first principal.
+func Chain(authenticators ...Authenticator) Authenticator {
+	return chain(authenticators)
+}
+
+type chain []Authenticator
+
+func (c chain) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	for _, a := range c {
+		p, err := a.Authenticate(ctx, token)
+		if err != nil || p != nil {
+			return p, err
+		}
+	}
+	return nil, nil
+}
+
+// TokenAuthenticator accepts the tokens issued by the token service and, when
+// set, a static admin token from the configuration that is used to issue the
+// first tokens.
+type TokenAuthenticator struct {
+	tokens         *service.TokenService
+	adminTokenHash string
+}
+
+func NewTokenAuthenticator(tokens *service.TokenService, adminToken string) *TokenAuthenticator {
+	a := &TokenAuthenticator{tokens: tokens}
+	if adminToken != "" {
+		a.adminTokenHash = model.HashToken(adminToken)
+	}
+	return a
+}
+
+func (a *TokenAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if a.adminTokenHash != "" && subtle.ConstantTimeCompare([]byte(model.HashToken(token)), []byte(a.adminTokenHash)) == 1 {
+		return &Principal{TokenID: "config", Role: model.RoleAdmin}, nil
+	}
+
+	t, err := a.tokens.Authenticate(ctx, token)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	return &Principal{TokenID: t.ID, Role: t.Role, UserID: t.UserID}, nil
+}
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+// minReload limits how often a token with an unknown key ID can make the key
+// set reload, so that forged key IDs cannot hammer the identity provider.
+const minReload = 10 * time.Second
+
+// maxJWKSBytes bounds the key set document fetched from a URL.
+const maxJWKSBytes = 1 << 20
+
+// KeySet is a JWKS that is loaded on first use and cached for the refresh
+// interval. A token signed with a key the cached set does not contain reloads
+// it early, which picks up keys the identity provider has rotated in. When a
+// reload fails the previous keys stay in use.
+type KeySet struct {
+	source  string
+	load    func(ctx context.Context) ([]byte, error)
+	refresh time.Duration
+
+	mu       sync.Mutex
+	keys     *jose.JSONWebKeySet
+	loadedAt time.Time
+	triedAt  time.Time
+	loadErr  error
+	// loading is closed when the fetch in flight completes; nil when none is.
+	loading chan struct{}
+}
+
+// NewFileKeySet reads the key set from a file, so a rotated file is picked up
+// without a restart.
+func NewFileKeySet(path string, refresh time.Duration) *KeySet {
+	return &KeySet{
+		source:  path,
+		refresh: refresh,
+		load: func(context.Context) ([]byte, error) {
+			return os.ReadFile(path)
+		},
+	}
+}
+
+// NewURLKeySet fetches the key set from the jwks_uri of the identity provider.
+func NewURLKeySet(url string, refresh time.Duration) *KeySet {
+	client := &http.Client{Timeout: 5 * time.Second}
+	return &KeySet{
+		source:  url,
+		refresh: refresh,
+		load: func(ctx context.Context) ([]byte, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Accept", "application/json")
+			resp, err := client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("unexpected status %s", resp.Status)
+			}
+			return io.ReadAll(io.LimitReader(resp.Body, maxJWKSBytes))
+		},
+	}
+}
+
+// Keys returns the keys with the given key ID, or every key when kid is empty.
+// The key set is fetched without holding mu: concurrent callers share one
+// fetch, and only callers that cannot do without it wait for it. A stale but
+// loaded set keeps serving while the refresh runs.
+func (k *KeySet) Keys(ctx context.Context, kid string) ([]jose.JSONWebKey, error) {
+	k.mu.Lock()
+	now := time.Now()
+	stale := k.keys == nil || now.Sub(k.loadedAt) >= k.refresh
+	unknown := k.keys != nil && kid != "" && len(k.keys.Key(kid)) == 0
+	if (stale || unknown) && k.loading == nil && now.Sub(k.triedAt) >= minReload {
+		k.triedAt = now
+		k.loading = make(chan struct{})
+		// The fetch is shared, so it must not fail because this caller gave up.
+		go k.reload(context.WithoutCancel(ctx))
+	}
+	loading := k.loading
+	wait := k.keys == nil || unknown
+	k.mu.Unlock()
+
+	if loading != nil && wait {
+		select {
+		case <-loading:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.keys == nil {
+		if k.loadErr != nil {
+			return nil, fmt.Errorf("load jwks from %s: %w", k.source, k.loadErr)
+		}
+		return nil, fmt.Errorf("jwks from %s is not loaded", k.source)
+	}
+
+	if kid == "" {
+		return k.keys.Keys, nil
+	}
+	return k.keys.Key(kid), nil
+}
+
+// reload fetches the key set, swaps it in under mu and wakes the callers
+// waiting on loading. On failure the previous keys stay in use.
+func (k *KeySet) reload(ctx context.Context) {
+	keys, err := k.fetch(ctx)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if err == nil {
+		k.keys = keys
+		k.loadedAt = time.Now()
+	} else if k.keys != nil {
+		slog.WarnContext(ctx, "auth: reload jwks, keeping previous keys", "source", k.source, "error", err)
+	}
+	k.loadErr = err
+	close(k.loading)
+	k.loading = nil
+}
+
+func (k *KeySet) fetch(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	data, err := k.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, err
+	}
+	if len(keys.Keys) == 0 {
+		return nil, errors.New("key set has no keys")
+	}
+	return &keys, nil
+}
@@ -0,0 +1,274 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+type testKey struct {
+	kid  string
+	priv *ecdsa.PrivateKey
+}
+
+func newTestKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testKey{kid: kid, priv: priv}
+}
+
+func (k testKey) public() jose.JSONWebKey {
+	return jose.JSONWebKey{Key: &k.priv.PublicKey, KeyID: k.kid, Algorithm: string(jose.ES256), Use: "sig"}
+}
+
+// keySource serves a key set that tests can rotate, and counts the loads.
+type keySource struct {
+	mu    sync.Mutex
+	keys  []jose.JSONWebKey
+	err   error
+	loads int
+	// block, when set, holds every load until it is closed.
+	block chan struct{}
+}
+
+func newKeySource(keys ...testKey) *keySource {
+	s := &keySource{}
+	s.set(keys...)
+	return s
+}
+
+func (s *keySource) set(keys ...testKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = nil
+	for _, k := range keys {
+		s.keys = append(s.keys, k.public())
+	}
+}
+
+func (s *keySource) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *keySource) loadCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loads
+}
+
+func (s *keySource) load(context.Context) ([]byte, error) {
+	s.mu.Lock()
+	s.loads++
+	block := s.block
+	s.mu.Unlock()
+	if block != nil {
+		<-block
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	return json.Marshal(jose.JSONWebKeySet{Keys: s.keys})
+}
+
+func newTestKeySet(src *keySource) *KeySet {
+	return &KeySet{source: "test", refresh: time.Hour, load: src.load}
+}
+
+// allowReload lifts the limit on reloads for unknown key IDs.
+func allowReload(k *KeySet) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.triedAt = time.Time{}
+}
+
+func keyIDs(keys []jose.JSONWebKey) []string {
+	ids := make([]string, 0, len(keys))
+	for _, k := range keys {
+		ids = append(ids, k.KeyID)
+	}
+	return ids
+}
+
+func TestKeySetLoadsOnFirstUse(t *testing.T) {
+	src := newKeySource(newTestKey(t, "a"), newTestKey(t, "b"))
+	ks := newTestKeySet(src)
+
+	keys, err := ks.Keys(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Keys: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Errorf("Keys = %v, want both keys", keyIDs(keys))
+	}
+	if _, err := ks.Keys(context.Background(), "a"); err != nil {
+		t.Fatalf("Keys: %v", err)
+	}
+	if n := src.loadCount(); n != 1 {
+		t.Errorf("loads = %d, want 1", n)
+	}
+}
+
+func TestKeySetFirstLoadError(t *testing.T) {
+	src := newKeySource()
+	src.fail(errors.New("connection refused"))
+	ks := newTestKeySet(src)
+
+	if _, err := ks.Keys(context.Background(), "a"); err == nil {
+		t.Fatal("Keys error = nil, want the load error")
+	}
+	if _, err := ks.Keys(context.Background(), "a"); err == nil {
+		t.Fatal("Keys error = nil on retry, want the load error")
+	}
+	if n := src.loadCount(); n != 1 {
+		t.Errorf("loads = %d, want 1 within the reload limit", n)
+	}
+}
+
+func TestKeySetReloadsOnUnknownKid(t *testing.T) {
+	a, b := newTestKey(t, "a"), newTestKey(t, "b")
+	src := newKeySource(a)
+	ks := newTestKeySet(src)
+	ctx := context.Background()
+
+	if _, err := ks.Keys(ctx, "a"); err != nil {
+		t.Fatalf("Keys: %v", err)
+	}
+	src.set(a, b)
+
+	keys, err := ks.Keys(ctx, "b")
+	if err != nil || len(keys) != 0 {
+		t.Fatalf("Keys(b) within the reload limit = %v, %v, want no keys", keyIDs(keys), err)
+	}
+	if n := src.loadCount(); n != 1 {
+		t.Fatalf("loads = %d, want 1 within the reload limit", n)
+	}
+
+	allowReload(ks)
+	keys, err = ks.Keys(ctx, "b")
+	if err != nil || len(keys) != 1 || keys[0].KeyID != "b" {
+		t.Fatalf("Keys(b) = %v, %v, want key b", keyIDs(keys), err)
+	}
+	if n := src.loadCount(); n != 2 {
+		t.Errorf("loads = %d, want 2", n)
+	}
+}
+
+func TestKeySetKeepsKeysWhenReloadFails(t *testing.T) {
+	src := newKeySource(newTestKey(t, "a"))
+	ks := newTestKeySet(src)
+	ctx := context.Background()
+
+	if _, err := ks.Keys(ctx, "a"); err != nil {
+		t.Fatalf("Keys: %v", err)
+	}
+	src.fail(errors.New("connection refused"))
+	allowReload(ks)
+
+	if keys, err := ks.Keys(ctx, "unknown"); err != nil || len(keys) != 0 {
+		t.Fatalf("Keys(unknown) = %v, %v, want no keys and no error", keyIDs(keys), err)
+	}
+	keys, err := ks.Keys(ctx, "a")
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("Keys(a) after a failed reload = %v, %v, want key a", keyIDs(keys), err)
+	}
+}
+
+func TestKeySetServesStaleKeysDuringRefresh(t *testing.T) {
+	src := newKeySource(newTestKey(t, "a"))
+	ks := newTestKeySet(src)
+	ctx := context.Background()
+
+	if _, err := ks.Keys(ctx, "a"); err != nil {
+		t.Fatalf("Keys: %v", err)
+	}
+
+	block := make(chan struct{})
+	defer close(block)
+	src.mu.Lock()
+	src.block = block
+	src.mu.Unlock()
+	ks.mu.Lock()
+	ks.loadedAt = time.Time{}
+	ks.triedAt = time.Time{}
+	ks.mu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := ks.Keys(ctx, "a")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Keys: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Keys waited for the refresh of a loaded key set")
+	}
+	deadline := time.Now().Add(time.Second)
+	for src.loadCount() != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("the refresh did not start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestKeySetSharesOneFetch(t *testing.T) {
+	src := newKeySource(newTestKey(t, "a"))
+	block := make(chan struct{})
+	src.block = block
+	ks := newTestKeySet(src)
+
+	const callers = 10
+	errs := make(chan error, callers)
+	for range callers {
+		go func() {
+			_, err := ks.Keys(context.Background(), "a")
+			errs <- err
+		}()
+	}
+	for src.loadCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(block)
+
+	for range callers {
+		if err := <-errs; err != nil {
+			t.Errorf("Keys: %v", err)
+		}
+	}
+	if n := src.loadCount(); n != 1 {
+		t.Errorf("loads = %d, want 1 shared fetch", n)
+	}
+}
+
+func TestKeySetWaitStopsWithContext(t *testing.T) {
+	src := newKeySource(newTestKey(t, "a"))
+	block := make(chan struct{})
+	defer close(block)
+	src.block = block
+	ks := newTestKeySet(src)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := ks.Keys(ctx, "a"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Keys error = %v, want context.DeadlineExceeded", err)
+	}
+}
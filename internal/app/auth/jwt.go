@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"test/internal/domain/model"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+// signatureAlgorithms are the asymmetric algorithms accepted for JWTs. HMAC
+// is left out on purpose: the keys come from a public key set.
+var signatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// clockSkew is the leeway for the time claims of a JWT.
+const clockSkew = time.Minute
+
+type JWTConfig struct {
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string
+	Audience string
+	// UserClaim holds the users.id of the caller and RoleClaim its role. A
+	// dotted name such as "realm_access.roles" selects a nested claim.
+	UserClaim string
+	RoleClaim string
+	// Roles maps values of the role claim to roles. The values ADMIN and USER
+	// map to themselves.
+	Roles map[string]model.Role
+}
+
+// JWTAuthenticator accepts JWTs signed by the identity provider with a key of
+// its key set. Tokens that are not JWTs are left to other authenticators.
+type JWTAuthenticator struct {
+	keys *KeySet
+	cfg  JWTConfig
+}
+
+func NewJWTAuthenticator(keys *KeySet, cfg JWTConfig) *JWTAuthenticator {
+	return &JWTAuthenticator{keys: keys, cfg: cfg}
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if strings.Count(token, ".") != 2 {
+		return nil, nil
+	}
+
+	tok, err := jwt.ParseSigned(token, signatureAlgorithms)
+	if err != nil {
+		return a.reject(ctx, "malformed token", err)
+	}
+	header := tok.Headers[0]
+	keys, err := a.keys.Keys(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var std jwt.Claims
+	var claims map[string]any
+	verified := false
+	for _, key := range keys {
+		if (key.Algorithm != "" && key.Algorithm != header.Algorithm) || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		if err := tok.Claims(key.Key, &std, &claims); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return a.reject(ctx, "no key of the key set verifies the signature", nil)
+	}
+
+	if std.Expiry == nil {
+		return a.reject(ctx, "exp claim is missing", nil)
+	}
+	expected := jwt.Expected{Issuer: a.cfg.Issuer, Time: time.Now()}
+	if a.cfg.Audience != "" {
+		expected.AnyAudience = jwt.Audience{a.cfg.Audience}
+	}
+	if err := std.ValidateWithLeeway(expected, clockSkew); err != nil {
+		return a.reject(ctx, "invalid claims", err)
+	}
+
+	userID, _ := claim(claims, a.cfg.UserClaim).(string)
+	if userID == "" {
+		return a.reject(ctx, fmt.Sprintf("%s claim is missing", a.cfg.UserClaim), nil)
+	}
+
+	tokenID := "jwt"
+	if std.ID != "" {
+		tokenID = "jwt:" + std.ID
+	}
+	return &Principal{TokenID: tokenID, Role: a.role(claim(claims, a.cfg.RoleClaim)), UserID: userID}, nil
+}
+
+// role maps the role claim, a string or a list of strings, to a role. ADMIN
+// wins over USER, and a caller without a known role is a USER.
+func (a *JWTAuthenticator) role(value any) model.Role {
+	var values []string
+	switch v := value.(type) {
+	case string:
+		values = []string{v}
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	for _, v := range values {
+		role, ok := a.cfg.Roles[v]
+		if !ok {
+			role = model.Role(v)
+		}
+		if role == model.RoleAdmin {
+			return model.RoleAdmin
+		}
+	}
+	return model.RoleUser
+}
+
+func (a *JWTAuthenticator) reject(ctx context.Context, reason string, err error) (*Principal, error) {
+	attrs := []any{"reason", reason}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	slog.DebugContext(ctx, "auth: reject jwt", attrs...)
+	return nil, nil
+}
+
+// claim looks up a claim by its dotted path.
+func claim(claims map[string]any, path string) any {
+	var value any = claims
+	for _, name := range strings.Split(path, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = m[name]
+	}
+	return value
+}
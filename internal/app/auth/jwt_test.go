@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"test/internal/domain/model"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+const (
+	testIssuer   = "https://sso.example.com/realms/dev"
+	testAudience = "pr-service"
+)
+
+func testJWTConfig() JWTConfig {
+	return JWTConfig{
+		Issuer:    testIssuer,
+		Audience:  testAudience,
+		UserClaim: "sub",
+		RoleClaim: "role",
+	}
+}
+
+// validClaims returns the claims of a token the test configuration accepts.
+func validClaims(userID string) map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss": testIssuer,
+		"aud": testAudience,
+		"sub": userID,
+		"iat": now.Unix(),
+		"exp": now.Add(5 * time.Minute).Unix(),
+	}
+}
+
+func signToken(t *testing.T, alg jose.SignatureAlgorithm, kid string, key any, claims map[string]any) string {
+	t.Helper()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: alg, Key: jose.JSONWebKey{Key: key, KeyID: kid}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func (k testKey) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	return signToken(t, jose.ES256, k.kid, k.priv, claims)
+}
+
+// unsignedToken builds a token with "alg": "none" and an empty signature.
+func unsignedToken(t *testing.T, kid string, claims map[string]any) string {
+	t.Helper()
+	part := func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	return part(map[string]string{"alg": "none", "typ": "JWT", "kid": kid}) + "." + part(claims) + "."
+}
+
+func authenticate(t *testing.T, a *JWTAuthenticator, token string) *Principal {
+	t.Helper()
+	p, err := a.Authenticate(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	return p
+}
+
+func TestJWTAuthenticatorAcceptsValidToken(t *testing.T) {
+	key := newTestKey(t, "a")
+	a := NewJWTAuthenticator(newTestKeySet(newKeySource(key)), testJWTConfig())
+
+	claims := validClaims("u1")
+	claims["jti"] = "42"
+	got := authenticate(t, a, key.sign(t, claims))
+
+	want := Principal{TokenID: "jwt:42", Role: model.RoleUser, UserID: "u1"}
+	if got == nil || *got != want {
+		t.Errorf("Authenticate = %+v, want %+v", got, want)
+	}
+}
+
+func TestJWTAuthenticatorRejectsInvalidClaims(t *testing.T) {
+	key := newTestKey(t, "a")
+	a := NewJWTAuthenticator(newTestKeySet(newKeySource(key)), testJWTConfig())
+
+	tests := []struct {
+		name  string
+		patch func(claims map[string]any)
+	}{
+		{"expired", func(c map[string]any) { c["exp"] = time.Now().Add(-2 * clockSkew).Unix() }},
+		{"not yet valid", func(c map[string]any) { c["nbf"] = time.Now().Add(2 * clockSkew).Unix() }},
+		{"no exp", func(c map[string]any) { delete(c, "exp") }},
+		{"wrong audience", func(c map[string]any) { c["aud"] = "another-service" }},
+		{"no audience", func(c map[string]any) { delete(c, "aud") }},
+		{"wrong issuer", func(c map[string]any) { c["iss"] = "https://evil.example.com" }},
+		{"no user", func(c map[string]any) { delete(c, "sub") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims("u1")
+			tt.patch(claims)
+			if got := authenticate(t, a, key.sign(t, claims)); got != nil {
+				t.Errorf("Authenticate = %+v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorAcceptsAudienceList(t *testing.T) {
+	key := newTestKey(t, "a")
+	a := NewJWTAuthenticator(newTestKeySet(newKeySource(key)), testJWTConfig())
+
+	claims := validClaims("u1")
+	claims["aud"] = []string{"account", testAudience}
+	if got := authenticate(t, a, key.sign(t, claims)); got == nil {
+		t.Error("Authenticate = nil, want a principal")
+	}
+}
+
+func TestJWTAuthenticatorRejectsForeignSignature(t *testing.T) {
+	key := newTestKey(t, "a")
+	a := NewJWTAuthenticator(newTestKeySet(newKeySource(key)), testJWTConfig())
+
+	forged := newTestKey(t, "a")
+	if got := authenticate(t, a, forged.sign(t, validClaims("u1"))); got != nil {
+		t.Errorf("Authenticate = %+v, want nil", got)
+	}
+}
+
+func TestJWTAuthenticatorRejectsSymmetricAndUnsignedTokens(t *testing.T) {
+	key := newTestKey(t, "a")
+	a := NewJWTAuthenticator(newTestKeySet(newKeySource(key)), testJWTConfig())
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"HS256", signToken(t, jose.HS256, "a", []byte("0123456789abcdef0123456789abcdef"), validClaims("u1"))},
+		{"none", unsignedToken(t, "a", validClaims("u1"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := authenticate(t, a, tt.token); got != nil {
+				t.Errorf("Authenticate = %+v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorIgnoresOpaqueTokens(t *testing.T) {
+	src := newKeySource(newTestKey(t, "a"))
+	a := NewJWTAuthenticator(newTestKeySet(src), testJWTConfig())
+
+	if got := authenticate(t, a, "3q2-7wAAAAAAAAAAAAAAAAAAAAAAAAAA"); got != nil {
+		t.Errorf("Authenticate = %+v, want nil", got)
+	}
+	if n := src.loadCount(); n != 0 {
+		t.Errorf("loads = %d, want the key set untouched", n)
+	}
+}
+
+func TestJWTAuthenticatorPicksUpRotatedKey(t *testing.T) {
+	oldKey, newKey := newTestKey(t, "2025"), newTestKey(t, "2026")
+	src := newKeySource(oldKey)
+	ks := newTestKeySet(src)
+	a := NewJWTAuthenticator(ks, testJWTConfig())
+
+	if got := authenticate(t, a, oldKey.sign(t, validClaims("u1"))); got == nil {
+		t.Fatal("Authenticate with the old key = nil, want a principal")
+	}
+
+	src.set(newKey)
+	allowReload(ks)
+	if got := authenticate(t, a, newKey.sign(t, validClaims("u1"))); got == nil {
+		t.Fatal("Authenticate with the rotated key = nil, want a principal")
+	}
+	if got := authenticate(t, a, oldKey.sign(t, validClaims("u1"))); got != nil {
+		t.Errorf("Authenticate with the retired key = %+v, want nil", got)
+	}
+}
+
+func TestJWTAuthenticatorMapsNestedRoleClaim(t *testing.T) {
+	key := newTestKey(t, "a")
+	cfg := testJWTConfig()
+	cfg.RoleClaim = "realm_access.roles"
+	cfg.Roles = map[string]model.Role{"pr-admin": model.RoleAdmin}
+	a := NewJWTAuthenticator(newTestKeySet(newKeySource(key)), cfg)
+
+	tests := []struct {
+		name  string
+		roles any
+		want  model.Role
+	}{
+		{"mapped value", []string{"offline_access", "pr-admin"}, model.RoleAdmin},
+		{"role name", []string{"ADMIN"}, model.RoleAdmin},
+		{"single string", "pr-admin", model.RoleAdmin},
+		{"unknown values", []string{"offline_access"}, model.RoleUser},
+		{"missing", nil, model.RoleUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims("u1")
+			if tt.roles != nil {
+				claims["realm_access"] = map[string]any{"roles": tt.roles}
+			}
+			got := authenticate(t, a, key.sign(t, claims))
+			if got == nil || got.Role != tt.want {
+				t.Errorf("Authenticate = %+v, want role %s", got, tt.want)
+			}
+		})
+	}
+}
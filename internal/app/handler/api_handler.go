@@ -7,16 +7,20 @@ import (
 )
 
 type APIHandler struct {
-	team *TeamHandler
-	user *UserHandler
-	pr   *PrHandler
+	team    *TeamHandler
+	user    *UserHandler
+	pr      *PrHandler
+	webhook *WebhookHandler
+	token   *TokenHandler
 }
 
-func NewAPIHandler(team *TeamHandler, user *UserHandler, pr *PrHandler) *APIHandler {
+func NewAPIHandler(team *TeamHandler, user *UserHandler, pr *PrHandler, webhook *WebhookHandler, token *TokenHandler) *APIHandler {
 	return &APIHandler{
-		team: team,
-		user: user,
-		pr:   pr,
+		team:    team,
+		user:    user,
+		pr:      pr,
+		webhook: webhook,
+		token:   token,
 	}
 }
 
@@ -29,26 +33,53 @@ func WriteJSON(w http.ResponseWriter, status int, v any) {
 }
 
 func WriteJSONError(w http.ResponseWriter, status int, code api.ErrorResponseErrorCode, msg string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-
 	resp := api.ErrorResponse{}
 	resp.Error.Code = code
 	resp.Error.Message = msg
 
-	_ = json.NewEncoder(w).Encode(resp)
+	writeErrorResponse(w, status, resp)
+}
+
+// WriteRequestError answers requests whose parameters could not be parsed by
+// the generated router.
+func WriteRequestError(w http.ResponseWriter, r *http.Request, err error) {
+	WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, err.Error())
 }
 
 func (h *APIHandler) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
 	h.pr.PostPullRequestCreate(w, r)
 }
 
-func (h *APIHandler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
-	h.pr.PostPullRequestMerge(w, r)
+func (h *APIHandler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request, params api.PostPullRequestMergeParams) {
+	h.pr.PostPullRequestMerge(w, r, params)
+}
+
+func (h *APIHandler) PostPullRequestReview(w http.ResponseWriter, r *http.Request, params api.PostPullRequestReviewParams) {
+	h.pr.PostPullRequestReview(w, r, params)
+}
+
+func (h *APIHandler) PostPullRequestClose(w http.ResponseWriter, r *http.Request, params api.PostPullRequestCloseParams) {
+	h.pr.PostPullRequestClose(w, r, params)
 }
 
-func (h *APIHandler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
-	h.pr.PostPullRequestReassign(w, r)
+func (h *APIHandler) PostPullRequestReopen(w http.ResponseWriter, r *http.Request, params api.PostPullRequestReopenParams) {
+	h.pr.PostPullRequestReopen(w, r, params)
+}
+
+func (h *APIHandler) PostPullRequestReady(w http.ResponseWriter, r *http.Request, params api.PostPullRequestReadyParams) {
+	h.pr.PostPullRequestReady(w, r, params)
+}
+
+func (h *APIHandler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request, params api.PostPullRequestReassignParams) {
+	h.pr.PostPullRequestReassign(w, r, params)
+}
+
+func (h *APIHandler) GetPullRequestGet(w http.ResponseWriter, r *http.Request, params api.GetPullRequestGetParams) {
+	h.pr.GetPullRequestGet(w, r, params)
+}
+
+func (h *APIHandler) GetPullRequestHistory(w http.ResponseWriter, r *http.Request, params api.GetPullRequestHistoryParams) {
+	h.pr.GetPullRequestHistory(w, r, params)
 }
 
 func (h *APIHandler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
@@ -59,10 +90,46 @@ func (h *APIHandler) GetTeamGet(w http.ResponseWriter, r *http.Request, params a
 	h.team.GetTeamGet(w, r, params)
 }
 
+func (h *APIHandler) GetTeamSettingsGet(w http.ResponseWriter, r *http.Request, params api.GetTeamSettingsGetParams) {
+	h.team.GetTeamSettingsGet(w, r, params)
+}
+
+func (h *APIHandler) PostTeamSettingsSet(w http.ResponseWriter, r *http.Request) {
+	h.team.PostTeamSettingsSet(w, r)
+}
+
 func (h *APIHandler) GetUsersGetReview(w http.ResponseWriter, r *http.Request, params api.GetUsersGetReviewParams) {
 	h.user.GetUsersGetReview(w, r, params)
 }
 
-func (h *APIHandler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
-	h.user.PostUsersSetIsActive(w, r)
+func (h *APIHandler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request, params api.PostUsersSetIsActiveParams) {
+	h.user.PostUsersSetIsActive(w, r, params)
+}
+
+func (h *APIHandler) PostWebhooksAdd(w http.ResponseWriter, r *http.Request) {
+	h.webhook.PostWebhooksAdd(w, r)
+}
+
+func (h *APIHandler) GetWebhooksList(w http.ResponseWriter, r *http.Request) {
+	h.webhook.GetWebhooksList(w, r)
+}
+
+func (h *APIHandler) PostWebhooksDelete(w http.ResponseWriter, r *http.Request) {
+	h.webhook.PostWebhooksDelete(w, r)
+}
+
+func (h *APIHandler) GetWebhooksDeliveries(w http.ResponseWriter, r *http.Request, params api.GetWebhooksDeliveriesParams) {
+	h.webhook.GetWebhooksDeliveries(w, r, params)
+}
+
+func (h *APIHandler) PostAdminTokensCreate(w http.ResponseWriter, r *http.Request) {
+	h.token.PostAdminTokensCreate(w, r)
+}
+
+func (h *APIHandler) GetAdminTokensList(w http.ResponseWriter, r *http.Request) {
+	h.token.GetAdminTokensList(w, r)
+}
+
+func (h *APIHandler) PostAdminTokensRevoke(w http.ResponseWriter, r *http.Request) {
+	h.token.PostAdminTokensRevoke(w, r)
 }
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+	"test/internal/api"
+	"test/internal/app/auth"
+)
+
+// allowActAs reports whether the caller may act on behalf of userID and
+// answers 403 when not. Without authentication every request is allowed.
+func allowActAs(w http.ResponseWriter, r *http.Request, userID string) bool {
+	p := auth.FromContext(r.Context())
+	if p == nil || p.CanActAs(userID) {
+		return true
+	}
+	WriteJSONError(w, http.StatusForbidden, api.FORBIDDEN, "a USER token may only act for its own user")
+	return false
+}
+
+// allowAdmin reports whether the caller is an admin and answers 403 with msg
+// when not. Without authentication every request is allowed.
+func allowAdmin(w http.ResponseWriter, r *http.Request, msg string) bool {
+	p := auth.FromContext(r.Context())
+	if p == nil || p.IsAdmin() {
+		return true
+	}
+	WriteJSONError(w, http.StatusForbidden, api.FORBIDDEN, msg)
+	return false
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"test/internal/api"
+	"test/internal/domain/domain_errors"
+	"test/internal/logging"
+)
+
+// errorMapping is how a domain error code is answered over HTTP. An empty
+// message means the message of the domain error is used.
+type errorMapping struct {
+	status  int
+	code    api.ErrorResponseErrorCode
+	message string
+}
+
+// errorMappings is the single place domain errors are translated to HTTP.
+// Codes missing here are answered as internal errors.
+var errorMappings = map[domain_errors.Code]errorMapping{
+	domain_errors.CodeUserNotFound:        {status: http.StatusNotFound, code: api.NOTFOUND},
+	domain_errors.CodeTeamNotFound:        {status: http.StatusNotFound, code: api.NOTFOUND},
+	domain_errors.CodePullRequestNotFound: {status: http.StatusNotFound, code: api.NOTFOUND},
+	domain_errors.CodeWebhookNotFound:     {status: http.StatusNotFound, code: api.NOTFOUND},
+	domain_errors.CodeTokenNotFound:       {status: http.StatusNotFound, code: api.NOTFOUND},
+
+	domain_errors.CodePullRequestExists:       {status: http.StatusConflict, code: api.PREXISTS},
+	domain_errors.CodeTeamExists:              {status: http.StatusConflict, code: api.TEAMEXISTS},
+	domain_errors.CodePRMerged:                {status: http.StatusConflict, code: api.PRMERGED},
+	domain_errors.CodeReviewerNotAssigned:     {status: http.StatusConflict, code: api.NOTASSIGNED},
+	domain_errors.CodeNoReplacementCandidate:  {status: http.StatusConflict, code: api.NOCANDIDATE},
+	domain_errors.CodeUserHasOpenPullRequests: {status: http.StatusConflict, code: api.USERHASOPENPRS},
+	domain_errors.CodeInvalidStatusTransition: {status: http.StatusConflict, code: api.INVALIDSTATUS},
+	domain_errors.CodePRNotOpen:               {status: http.StatusConflict, code: api.PRNOTOPEN},
+	domain_errors.CodeNotEnoughApprovals:      {status: http.StatusConflict, code: api.NOTAPPROVED},
+
+	domain_errors.CodeInvalidTeamSettings: {
+		status:  http.StatusBadRequest,
+		code:    api.VALIDATIONERROR,
+		message: "reviewers_per_pr and min_approvals must be between 0 and 10, min_approvals must not exceed reviewers_per_pr, selection_strategy must be a known strategy and fallback_teams must be distinct other teams",
+	},
+	domain_errors.CodeFallbackTeamNotFound: {status: http.StatusBadRequest, code: api.VALIDATIONERROR},
+	domain_errors.CodeInvalidReviewDecision: {
+		status:  http.StatusBadRequest,
+		code:    api.VALIDATIONERROR,
+		message: "decision must be APPROVED or CHANGES_REQUESTED",
+	},
+	domain_errors.CodeInvalidWebhook: {
+		status:  http.StatusBadRequest,
+		code:    api.VALIDATIONERROR,
+		message: "url must be an absolute http(s) URL and event_types must be known event types",
+	},
+	domain_errors.CodeInvalidToken: {
+		status:  http.StatusBadRequest,
+		code:    api.VALIDATIONERROR,
+		message: "role must be ADMIN or USER and USER tokens need a user_id",
+	},
+
+	domain_errors.CodeVersionMismatch: {
+		status:  http.StatusPreconditionFailed,
+		code:    api.VERSIONMISMATCH,
+		message: "resource was modified, reload it and retry",
+	},
+}
+
+// WriteError answers a failed service call. Domain errors are written with the
+// status, code and details from errorMappings, anything else as an internal
+// error.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *domain_errors.Error
+	if !errors.As(err, &domainErr) {
+		WriteInternalError(w, r, err)
+		return
+	}
+	m, ok := errorMappings[domainErr.Code]
+	if !ok {
+		WriteInternalError(w, r, err)
+		return
+	}
+
+	resp := api.ErrorResponse{}
+	resp.Error.Code = m.code
+	resp.Error.Message = m.message
+	if resp.Error.Message == "" {
+		resp.Error.Message = domainErr.Message
+	}
+	if len(domainErr.Details) > 0 {
+		details := domainErr.Details
+		resp.Error.Details = &details
+	}
+	writeErrorResponse(w, m.status, resp)
+}
+
+// WriteInternalError logs err with the request context and answers 500 without
+// exposing the error text to the client. The response carries the request ID
+// so the log record can be found.
+func WriteInternalError(w http.ResponseWriter, r *http.Request, err error) {
+	slog.ErrorContext(r.Context(), "request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+
+	resp := api.ErrorResponse{}
+	resp.Error.Code = api.INTERNAL
+	resp.Error.Message = "internal error"
+	if id := logging.RequestID(r.Context()); id != "" {
+		resp.Error.CorrelationId = &id
+	}
+	writeErrorResponse(w, http.StatusInternalServerError, resp)
+}
+
+func writeErrorResponse(w http.ResponseWriter, status int, resp api.ErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
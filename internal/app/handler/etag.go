@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var errInvalidIfMatch = errors.New(`If-Match must be a single ETag such as "3" or *`)
+
+// setETag exposes the version of the returned resource as a strong ETag.
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", `"`+strconv.FormatInt(version, 10)+`"`)
+}
+
+// parseIfMatch returns the version required by an If-Match header, or 0 when
+// the header is absent or "*".
+func parseIfMatch(ifMatch *string) (int64, error) {
+	if ifMatch == nil {
+		return 0, nil
+	}
+
+	v := strings.TrimSpace(*ifMatch)
+	if v == "" || v == "*" {
+		return 0, nil
+	}
+
+	v = strings.TrimPrefix(v, "W/")
+	if len(v) < 2 || v[0] != '"' || v[len(v)-1] != '"' {
+		return 0, errInvalidIfMatch
+	}
+
+	version, err := strconv.ParseInt(v[1:len(v)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errInvalidIfMatch
+	}
+	return version, nil
+}
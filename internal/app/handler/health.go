@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+	"test/internal/app/health"
+)
+
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+// GetHealthz reports that the process is up and serving HTTP.
+func (h *HealthHandler) GetHealthz(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, health.Report{Status: health.StatusOK, Checks: map[string]health.CheckResult{}})
+}
+
+// GetReadyz runs the dependency checks and answers 503 if any of them fails.
+func (h *HealthHandler) GetReadyz(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.Run(r.Context())
+
+	status := http.StatusOK
+	if report.Status != health.StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	WriteJSON(w, status, report)
+}
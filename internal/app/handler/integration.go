@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"test/internal/app/integration"
+	"test/internal/domain/domain_errors"
+	"test/internal/domain/service"
+)
+
+const maxIntegrationPayload = 5 << 20
+
+type IntegrationResponse struct {
+	Status        string `json:"status"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// IntegrationHandler mirrors pull requests of code hosting providers into PrService.
+type IntegrationHandler struct {
+	prService    *service.PrService
+	users        integration.UserMap
+	githubSecret string
+	gitlabToken  string
+}
+
+func NewIntegrationHandler(prService *service.PrService, users integration.UserMap, githubSecret, gitlabToken string) *IntegrationHandler {
+	return &IntegrationHandler{
+		prService:    prService,
+		users:        users,
+		githubSecret: githubSecret,
+		gitlabToken:  gitlabToken,
+	}
+}
+
+func (h *IntegrationHandler) PostGitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxIntegrationPayload))
+	if err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if err := integration.VerifyGitHub(r.Header, body, h.githubSecret); err != nil {
+		slog.WarnContext(r.Context(), "integration: rejected github webhook", "error", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := integration.ParseGitHub(r.Header, body)
+	h.handleEvent(w, r, event, err)
+}
+
+func (h *IntegrationHandler) PostGitLabWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := integration.VerifyGitLab(r.Header, h.gitlabToken); err != nil {
+		slog.WarnContext(r.Context(), "integration: rejected gitlab webhook", "error", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxIntegrationPayload))
+	if err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	event, err := integration.ParseGitLab(r.Header, body)
+	h.handleEvent(w, r, event, err)
+}
+
+// handleEvent applies event to the service. Deliveries that do not change
+// anything (unsupported events, repeated deliveries, unknown authors) are
+// acknowledged with status "ignored" so that the provider does not retry them.
+func (h *IntegrationHandler) handleEvent(w http.ResponseWriter, r *http.Request, event *integration.PullRequestEvent, err error) {
+	ctx := r.Context()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if event == nil {
+		WriteJSON(w, http.StatusOK, IntegrationResponse{Status: "ignored", Reason: "unsupported event"})
+		return
+	}
+
+	if err := h.apply(ctx, event); err != nil {
+		switch {
+		case errors.Is(err, domain_errors.ErrPullRequestExists),
+			errors.Is(err, domain_errors.ErrPullRequestNotFound),
+			errors.Is(err, domain_errors.ErrUserNotFound),
+			errors.Is(err, domain_errors.ErrPRMerged),
+			errors.Is(err, domain_errors.ErrInvalidStatusTransition):
+			slog.InfoContext(ctx, "integration: event ignored", "action", event.Action, "pull_request_id", event.PullRequestID, "reason", err.Error())
+			WriteJSON(w, http.StatusOK, IntegrationResponse{Status: "ignored", PullRequestID: event.PullRequestID, Reason: err.Error()})
+			return
+		default:
+			WriteInternalError(w, r, err)
+			return
+		}
+	}
+
+	slog.InfoContext(ctx, "integration: event processed", "action", event.Action, "pull_request_id", event.PullRequestID)
+	WriteJSON(w, http.StatusOK, IntegrationResponse{Status: "processed", PullRequestID: event.PullRequestID})
+}
+
+func (h *IntegrationHandler) apply(ctx context.Context, event *integration.PullRequestEvent) error {
+	var err error
+	switch event.Action {
+	case integration.ActionOpened:
+		_, err = h.prService.CreatePR(ctx, event.PullRequestID, event.Name, h.users.Resolve(event.Author), event.Draft)
+	case integration.ActionMerged:
+		// The PR is already merged on the hosting side, so missing approvals
+		// are recorded as an override instead of rejecting the event.
+		_, err = h.prService.Merge(ctx, event.PullRequestID, "", 0)
+		if errors.Is(err, domain_errors.ErrNotEnoughApprovals) {
+			_, err = h.prService.Merge(ctx, event.PullRequestID, "merged on the hosting side", 0)
+		}
+	case integration.ActionClosed:
+		_, err = h.prService.Close(ctx, event.PullRequestID, 0)
+	case integration.ActionReopened:
+		_, err = h.prService.Reopen(ctx, event.PullRequestID, 0)
+	case integration.ActionReady:
+		_, err = h.prService.MarkReady(ctx, event.PullRequestID, 0)
+	case integration.ActionDraft:
+		_, err = h.prService.MarkDraft(ctx, event.PullRequestID, 0)
+	}
+	return err
+}
@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
 	"test/internal/api"
+	"test/internal/app/auth"
 	"test/internal/app/mapper"
-	"test/internal/domain/domain_errors"
+	"test/internal/domain/model"
 	"test/internal/domain/service"
 )
 
@@ -15,6 +17,11 @@ type PostPullRequestReassignResponse struct {
 	ReplacedBy string          `json:"replaced_by"`
 }
 
+type PullRequestHistoryResponse struct {
+	PullRequestID string        `json:"pull_request_id"`
+	Events        []api.PrEvent `json:"events"`
+}
+
 type PrHandler struct {
 	prService *service.PrService
 }
@@ -26,7 +33,7 @@ func NewPrHandler(s *service.PrService) *PrHandler {
 func (h *PrHandler) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
 	var body api.PostPullRequestCreateJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
 		return
 	}
 
@@ -35,93 +42,97 @@ func (h *PrHandler) PostPullRequestCreate(w http.ResponseWriter, r *http.Request
 	authorID := strings.TrimSpace(body.AuthorId)
 
 	if prID == "" || prName == "" || authorID == "" {
-		http.Error(w, "pull_request_id, pull_request_name and author_id must not be empty", http.StatusBadRequest)
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "pull_request_id, pull_request_name and author_id must not be empty")
+		return
+	}
+	if !allowActAs(w, r, authorID) {
 		return
 	}
 
-	pr, err := h.prService.CreatePR(r.Context(), prID, prName, authorID)
+	draft := body.Draft != nil && *body.Draft
+
+	pr, err := h.prService.CreatePR(r.Context(), prID, prName, authorID, draft)
 	if err != nil {
-		switch err {
-		case domain_errors.ErrPullRequestExists:
-			WriteJSONError(w, http.StatusConflict, api.PREXISTS, "pull request already exists")
-			return
-		case domain_errors.ErrUserNotFound:
-			WriteJSONError(w, http.StatusNotFound, api.NOTFOUND, "author not found")
-			return
-		default:
-			http.Error(w, "internal error: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
+		WriteError(w, r, err)
+		return
 	}
 
 	resp := mapper.ToAPIPullRequest(pr)
+	setETag(w, pr.Version)
 	WriteJSON(w, http.StatusCreated, map[string]interface{}{"pr": resp})
 }
 
-func (h *PrHandler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
+func (h *PrHandler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request, params api.PostPullRequestMergeParams) {
 	var body api.PostPullRequestMergeJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
 		return
 	}
 
 	prID := strings.TrimSpace(body.PullRequestId)
 	if prID == "" {
-		http.Error(w, "pull_request_id must not be empty", http.StatusBadRequest)
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "pull_request_id must not be empty")
+		return
+	}
+
+	overrideReason := ""
+	if body.OverrideReason != nil {
+		overrideReason = strings.TrimSpace(*body.OverrideReason)
+	}
+	if overrideReason != "" && !allowAdmin(w, r, "only ADMIN tokens may merge with override_reason") {
+		return
+	}
+	if !h.allowParticipant(w, r, prID) {
 		return
 	}
 
-	pr, err := h.prService.Merge(r.Context(), prID)
+	version, err := parseIfMatch(params.IfMatch)
 	if err != nil {
-		switch err {
-		case domain_errors.ErrPullRequestNotFound:
-			WriteJSONError(w, http.StatusNotFound, api.NOTFOUND, "pull request not found")
-			return
-		default:
-			http.Error(w, "internal error: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, err.Error())
+		return
+	}
+
+	pr, err := h.prService.Merge(r.Context(), prID, overrideReason, version)
+	if err != nil {
+		WriteError(w, r, err)
+		return
 	}
 
 	resp := mapper.ToAPIPullRequest(pr)
+	setETag(w, pr.Version)
 	WriteJSON(w, http.StatusOK, map[string]interface{}{"pr": resp})
 }
 
-func (h *PrHandler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
+func (h *PrHandler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request, params api.PostPullRequestReassignParams) {
 	var body api.PostPullRequestReassignJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
 		return
 	}
 
 	prID := strings.TrimSpace(body.PullRequestId)
 	oldReviewerID := strings.TrimSpace(body.OldUserId)
 	if prID == "" || oldReviewerID == "" {
-		http.Error(w, "pull_request_id and old_user_id must not be empty", http.StatusBadRequest)
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "pull_request_id and old_user_id must not be empty")
+		return
+	}
+	if !h.allowReassign(w, r, prID, oldReviewerID) {
+		return
+	}
+
+	version, err := parseIfMatch(params.IfMatch)
+	if err != nil {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, err.Error())
 		return
 	}
 
-	pr, newReviewerID, err := h.prService.ReassignReviewer(r.Context(), prID, oldReviewerID)
+	pr, newReviewerID, err := h.prService.ReassignReviewer(r.Context(), prID, oldReviewerID, version)
 	if err != nil {
-		switch err {
-		case domain_errors.ErrPullRequestNotFound:
-			WriteJSONError(w, http.StatusNotFound, api.NOTFOUND, "pull request not found")
-			return
-		case domain_errors.ErrReviewerNotAssigned:
-			WriteJSONError(w, http.StatusConflict, api.NOTASSIGNED, "reviewer not assigned to PR")
-			return
-		case domain_errors.ErrPRMerged:
-			WriteJSONError(w, http.StatusConflict, api.PRMERGED, "cannot reassign merged PR")
-			return
-		case domain_errors.ErrNoReplacementCandidate:
-			WriteJSONError(w, http.StatusConflict, api.NOCANDIDATE, "no replacement candidate available")
-			return
-		case domain_errors.ErrUserNotFound:
-			WriteJSONError(w, http.StatusNotFound, api.NOTFOUND, "user not found")
-			return
-		}
+		WriteError(w, r, err)
+		return
 	}
 
+	setETag(w, pr.Version)
 	resp := PostPullRequestReassignResponse{
 		Pr:         mapper.ToAPIPullRequest(pr),
 		ReplacedBy: newReviewerID,
@@ -129,3 +140,181 @@ func (h *PrHandler) PostPullRequestReassign(w http.ResponseWriter, r *http.Reque
 
 	WriteJSON(w, http.StatusOK, resp)
 }
+
+func (h *PrHandler) PostPullRequestReview(w http.ResponseWriter, r *http.Request, params api.PostPullRequestReviewParams) {
+	var body api.PostPullRequestReviewJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
+		return
+	}
+
+	prID := strings.TrimSpace(body.PullRequestId)
+	reviewerID := strings.TrimSpace(body.ReviewerId)
+	if prID == "" || reviewerID == "" {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "pull_request_id and reviewer_id must not be empty")
+		return
+	}
+	if !allowActAs(w, r, reviewerID) {
+		return
+	}
+
+	version, err := parseIfMatch(params.IfMatch)
+	if err != nil {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, err.Error())
+		return
+	}
+
+	pr, err := h.prService.SubmitReview(r.Context(), prID, reviewerID, model.ReviewDecision(body.Decision), version)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := mapper.ToAPIPullRequest(pr)
+	setETag(w, pr.Version)
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"pr": resp})
+}
+
+func (h *PrHandler) PostPullRequestClose(w http.ResponseWriter, r *http.Request, params api.PostPullRequestCloseParams) {
+	var body api.PostPullRequestCloseJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
+		return
+	}
+
+	h.changeStatus(w, r, body.PullRequestId, params.IfMatch, h.prService.Close)
+}
+
+func (h *PrHandler) PostPullRequestReopen(w http.ResponseWriter, r *http.Request, params api.PostPullRequestReopenParams) {
+	var body api.PostPullRequestReopenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
+		return
+	}
+
+	h.changeStatus(w, r, body.PullRequestId, params.IfMatch, h.prService.Reopen)
+}
+
+func (h *PrHandler) PostPullRequestReady(w http.ResponseWriter, r *http.Request, params api.PostPullRequestReadyParams) {
+	var body api.PostPullRequestReadyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
+		return
+	}
+
+	h.changeStatus(w, r, body.PullRequestId, params.IfMatch, h.prService.MarkReady)
+}
+
+// changeStatus runs a lifecycle transition on behalf of a participant of the
+// pull request and writes the resulting pull request.
+func (h *PrHandler) changeStatus(w http.ResponseWriter, r *http.Request, rawID string, ifMatch *string, transition func(context.Context, string, int64) (*model.PullRequest, error)) {
+	prID := strings.TrimSpace(rawID)
+	if prID == "" {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "pull_request_id must not be empty")
+		return
+	}
+	if !h.allowParticipant(w, r, prID) {
+		return
+	}
+
+	version, err := parseIfMatch(ifMatch)
+	if err != nil {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, err.Error())
+		return
+	}
+
+	pr, err := transition(r.Context(), prID, version)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := mapper.ToAPIPullRequest(pr)
+	setETag(w, pr.Version)
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"pr": resp})
+}
+
+func (h *PrHandler) GetPullRequestGet(w http.ResponseWriter, r *http.Request, params api.GetPullRequestGetParams) {
+	prID := strings.TrimSpace(params.PullRequestId)
+	if prID == "" {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "pull_request_id must not be empty")
+		return
+	}
+
+	pr, err := h.prService.GetByID(r.Context(), prID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := mapper.ToAPIPullRequest(pr)
+	setETag(w, pr.Version)
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"pr": resp})
+}
+
+func (h *PrHandler) GetPullRequestHistory(w http.ResponseWriter, r *http.Request, params api.GetPullRequestHistoryParams) {
+	prID := strings.TrimSpace(params.PullRequestId)
+	if prID == "" {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "pull_request_id must not be empty")
+		return
+	}
+
+	events, err := h.prService.GetHistory(r.Context(), prID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := PullRequestHistoryResponse{
+		PullRequestID: prID,
+		Events:        make([]api.PrEvent, 0, len(events)),
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, mapper.ToAPIPrEvent(e))
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// allowReassign reports whether the caller may replace oldReviewerID on the
+// pull request: admins always, users when they are that reviewer or the author
+// of the pull request. It answers the request when not. Without
+// authentication everyone is allowed.
+func (h *PrHandler) allowReassign(w http.ResponseWriter, r *http.Request, prID, oldReviewerID string) bool {
+	p := auth.FromContext(r.Context())
+	if p == nil || p.IsAdmin() || p.UserID == oldReviewerID {
+		return true
+	}
+
+	pr, err := h.prService.GetByID(r.Context(), prID)
+	if err != nil {
+		WriteError(w, r, err)
+		return false
+	}
+	if pr.AuthorID != p.UserID {
+		WriteJSONError(w, http.StatusForbidden, api.FORBIDDEN, "only the reviewer being replaced or the author may reassign a reviewer")
+		return false
+	}
+	return true
+}
+
+// allowParticipant reports whether the caller may act on the pull request:
+// admins always, users when they are its author or one of its reviewers. It
+// answers the request when not. Without authentication everyone is allowed.
+func (h *PrHandler) allowParticipant(w http.ResponseWriter, r *http.Request, prID string) bool {
+	p := auth.FromContext(r.Context())
+	if p == nil || p.IsAdmin() {
+		return true
+	}
+
+	pr, err := h.prService.GetByID(r.Context(), prID)
+	if err != nil {
+		WriteError(w, r, err)
+		return false
+	}
+	if pr.AuthorID != p.UserID && !pr.HasReviewer(p.UserID) {
+		WriteJSONError(w, http.StatusForbidden, api.FORBIDDEN, "only the author or an assigned reviewer may change this pull request")
+		return false
+	}
+	return true
+}
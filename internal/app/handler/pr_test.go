@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"test/internal/api"
+	"test/internal/app/auth"
+	"test/internal/domain/model"
+	"test/internal/domain/service"
+	"test/internal/infrastructure/persistence/memory"
+)
+
+// newReassignFixture returns a handler over a store with team backend of
+// a1, b2, b3 and b4, and the open pull request pr-1 of a1 reviewed by b2 and b3.
+func newReassignFixture(t *testing.T) (*PrHandler, *memory.PrRepository) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewStore()
+	users := memory.NewUserRepository(store)
+	teams := memory.NewTeamRepository(store, users)
+	prs := memory.NewPrRepository(store)
+
+	var members []*model.User
+	for _, id := range []string{"a1", "b2", "b3", "b4"} {
+		members = append(members, model.NewUser(id, id, "backend", true))
+	}
+	if err := teams.Create(ctx, model.NewTeam("backend", members)); err != nil {
+		t.Fatal(err)
+	}
+	pr := model.NewPr("pr-1", "Add fallback teams", "a1")
+	pr.AssignReviewer("b2", "")
+	pr.AssignReviewer("b3", "")
+	if err := prs.Create(ctx, pr); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := service.NewPrService(prs, users, teams, memory.NewTxManager(store),
+		service.NewSelectorRegistry(prs, nil), service.NewRandomSelector(), service.NopMetrics{})
+	return NewPrHandler(svc), prs
+}
+
+func TestPostPullRequestReassignPermissions(t *testing.T) {
+	tests := []struct {
+		name       string
+		caller     *auth.Principal
+		oldUserID  string
+		wantStatus int
+	}{
+		{"reviewer replaces another reviewer", &auth.Principal{Role: model.RoleUser, UserID: "b2"}, "b3", http.StatusForbidden},
+		{"user outside the pull request", &auth.Principal{Role: model.RoleUser, UserID: "b4"}, "b2", http.StatusForbidden},
+		{"reviewer replaces themselves", &auth.Principal{Role: model.RoleUser, UserID: "b2"}, "b2", http.StatusOK},
+		{"author replaces a reviewer", &auth.Principal{Role: model.RoleUser, UserID: "a1"}, "b3", http.StatusOK},
+		{"admin", &auth.Principal{Role: model.RoleAdmin}, "b3", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, prs := newReassignFixture(t)
+
+			body := `{"pull_request_id":"pr-1","old_user_id":"` + tt.oldUserID + `"}`
+			r := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", strings.NewReader(body))
+			r = r.WithContext(auth.WithPrincipal(r.Context(), tt.caller))
+			w := httptest.NewRecorder()
+			h.PostPullRequestReassign(w, r, api.PostPullRequestReassignParams{})
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			pr, err := prs.GetByID(context.Background(), "pr-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			replaced := !pr.HasReviewer(tt.oldUserID)
+			if replaced != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("reviewers = %v after status %d", pr.AssignedReviewers, w.Code)
+			}
+		})
+	}
+}
@@ -3,10 +3,10 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 	"test/internal/api"
 	"test/internal/app/mapper"
-	"test/internal/domain/domain_errors"
 	"test/internal/domain/model"
 	"test/internal/domain/service"
 )
@@ -25,13 +25,13 @@ func (h *TeamHandler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 	var body api.Team
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
 		return
 	}
 
 	teamName := strings.TrimSpace(body.TeamName)
 	if teamName == "" {
-		http.Error(w, "team_name must not be empty", http.StatusBadRequest)
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "team_name must not be empty")
 		return
 	}
 
@@ -42,9 +42,8 @@ func (h *TeamHandler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 		username := strings.TrimSpace(m.Username)
 
 		if uid == "" || username == "" {
-			http.Error(w,
-				"user_id and username must not be empty for member index "+string(rune(i)),
-				http.StatusBadRequest)
+			WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR,
+				"user_id and username must not be empty for member index "+strconv.Itoa(i))
 			return
 		}
 
@@ -55,17 +54,8 @@ func (h *TeamHandler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 
 	team, err := h.teamService.CreateTeam(r.Context(), teamName, members)
 	if err != nil {
-		switch err {
-		case domain_errors.ErrTeamExists:
-			WriteJSONError(w, http.StatusBadRequest, api.TEAMEXISTS, "team already exists")
-			return
-		case domain_errors.ErrUserHasOpenPullRequests:
-			WriteJSONError(w, http.StatusBadRequest, api.PREXISTS, "some users have open PRs")
-			return
-		default:
-			http.Error(w, "internal error: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
+		WriteError(w, r, err)
+		return
 	}
 
 	resp := mapper.ToAPITeam(team)
@@ -75,22 +65,56 @@ func (h *TeamHandler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 func (h *TeamHandler) GetTeamGet(w http.ResponseWriter, r *http.Request, params api.GetTeamGetParams) {
 	teamName := strings.TrimSpace(params.TeamName)
 	if teamName == "" {
-		http.Error(w, "team_name must not be empty", http.StatusBadRequest)
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "team_name must not be empty")
 		return
 	}
 
 	team, err := h.teamService.GetTeam(r.Context(), params.TeamName)
 	if err != nil {
-		switch err {
-		case domain_errors.ErrTeamNotFound:
-			WriteJSONError(w, http.StatusNotFound, api.NOTFOUND, "team not found")
-			return
-		default:
-			http.Error(w, "internal error: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
+		WriteError(w, r, err)
+		return
 	}
 
 	resp := mapper.ToAPITeam(team)
 	WriteJSON(w, http.StatusOK, map[string]interface{}{"team": resp})
 }
+
+func (h *TeamHandler) GetTeamSettingsGet(w http.ResponseWriter, r *http.Request, params api.GetTeamSettingsGetParams) {
+	teamName := strings.TrimSpace(params.TeamName)
+	if teamName == "" {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "team_name must not be empty")
+		return
+	}
+
+	settings, err := h.teamService.GetSettings(r.Context(), teamName)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := mapper.ToAPITeamSettings(settings)
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"settings": resp})
+}
+
+func (h *TeamHandler) PostTeamSettingsSet(w http.ResponseWriter, r *http.Request) {
+	var body api.PostTeamSettingsSetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
+		return
+	}
+
+	body.TeamName = strings.TrimSpace(body.TeamName)
+	if body.TeamName == "" {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "team_name must not be empty")
+		return
+	}
+
+	settings, err := h.teamService.UpdateSettings(r.Context(), mapper.FromAPITeamSettings(body))
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := mapper.ToAPITeamSettings(settings)
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"settings": resp})
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"test/internal/api"
+	"test/internal/app/mapper"
+	"test/internal/domain/model"
+	"test/internal/domain/service"
+)
+
+type TokenHandler struct {
+	tokenService *service.TokenService
+}
+
+func NewTokenHandler(tokenService *service.TokenService) *TokenHandler {
+	return &TokenHandler{
+		tokenService: tokenService,
+	}
+}
+
+func (h *TokenHandler) PostAdminTokensCreate(w http.ResponseWriter, r *http.Request) {
+	var body api.PostAdminTokensCreateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
+		return
+	}
+
+	name := strings.TrimSpace(body.Name)
+	if name == "" {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "name must not be empty")
+		return
+	}
+	userID := ""
+	if body.UserId != nil {
+		userID = strings.TrimSpace(*body.UserId)
+	}
+
+	token, plain, err := h.tokenService.Create(r.Context(), name, model.Role(body.Role), userID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := mapper.ToAPIToken(token, plain)
+	WriteJSON(w, http.StatusCreated, map[string]interface{}{"token": resp})
+}
+
+func (h *TokenHandler) GetAdminTokensList(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.tokenService.List(r.Context())
+	if err != nil {
+		WriteInternalError(w, r, err)
+		return
+	}
+
+	resp := make([]api.ApiToken, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, mapper.ToAPIToken(token, ""))
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"tokens": resp})
+}
+
+func (h *TokenHandler) PostAdminTokensRevoke(w http.ResponseWriter, r *http.Request) {
+	var body api.PostAdminTokensRevokeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
+		return
+	}
+
+	tokenID := strings.TrimSpace(body.TokenId)
+	if tokenID == "" {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "token_id must not be empty")
+		return
+	}
+
+	if err := h.tokenService.Revoke(r.Context(), tokenID); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
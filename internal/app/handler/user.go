@@ -5,8 +5,8 @@ import (
 	"net/http"
 	"strings"
 	"test/internal/api"
+	"test/internal/app/auth"
 	"test/internal/app/mapper"
-	"test/internal/domain/domain_errors"
 	"test/internal/domain/service"
 )
 
@@ -25,15 +25,26 @@ func NewUserHandler(us *service.UserService, ps *service.PrService) *UserHandler
 }
 
 func (h *UserHandler) GetUsersGetReview(w http.ResponseWriter, r *http.Request, params api.GetUsersGetReviewParams) {
-	userId := strings.TrimSpace(params.UserId)
+	userId := ""
+	if params.UserId != nil {
+		userId = strings.TrimSpace(*params.UserId)
+	}
+	if userId == "" {
+		if p := auth.FromContext(r.Context()); p != nil {
+			userId = p.UserID
+		}
+	}
 	if userId == "" {
-		http.Error(w, "user_id must not be empty", http.StatusBadRequest)
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "user_id is required when the token does not belong to a user")
+		return
+	}
+	if !allowActAs(w, r, userId) {
 		return
 	}
 
 	prList, err := h.prService.GetByReviewer(r.Context(), userId)
 	if err != nil {
-		http.Error(w, "internal error: "+err.Error(), http.StatusInternalServerError)
+		WriteInternalError(w, r, err)
 		return
 	}
 
@@ -49,32 +60,34 @@ func (h *UserHandler) GetUsersGetReview(w http.ResponseWriter, r *http.Request,
 	WriteJSON(w, http.StatusOK, resp)
 }
 
-func (h *UserHandler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
+func (h *UserHandler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request, params api.PostUsersSetIsActiveParams) {
 	var body api.PostUsersSetIsActiveJSONBody
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
 		return
 	}
 
 	if strings.TrimSpace(body.UserId) == "" {
-		http.Error(w, "user_id must not be empty", http.StatusBadRequest)
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "user_id must not be empty")
 		return
 	}
 
-	u, err := h.userService.SetIsActive(r.Context(), body.UserId, body.IsActive)
+	version, err := parseIfMatch(params.IfMatch)
 	if err != nil {
-		switch err {
-		case domain_errors.ErrUserNotFound:
-			WriteJSONError(w, http.StatusNotFound, api.NOTFOUND, "user not found")
-			return
-		default:
-			http.Error(w, "internal error: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, err.Error())
+		return
+	}
+
+	u, err := h.userService.SetIsActive(r.Context(), body.UserId, body.IsActive, version)
+	if err != nil {
+		WriteError(w, r, err)
+		return
 	}
 
 	apiUser := mapper.ToAPIUser(u)
+	setETag(w, u.Version)
 
-	WriteJSON(w, http.StatusOK, map[string]interface{}{"team": apiUser})
+	// "team" is the key this endpoint used before; it stays for existing clients.
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"user": apiUser, "team": apiUser})
 }
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"test/internal/api"
+	"test/internal/app/mapper"
+	"test/internal/domain/model"
+	"test/internal/domain/service"
+)
+
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+}
+
+func NewWebhookHandler(webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+func (h *WebhookHandler) PostWebhooksAdd(w http.ResponseWriter, r *http.Request) {
+	var body api.PostWebhooksAddJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
+		return
+	}
+
+	secret := ""
+	if body.Secret != nil {
+		secret = *body.Secret
+	}
+	var eventTypes []string
+	if body.EventTypes != nil {
+		eventTypes = *body.EventTypes
+	}
+
+	sub, err := h.webhookService.Subscribe(r.Context(), strings.TrimSpace(body.Url), secret, eventTypes)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := mapper.ToAPIWebhookSubscription(sub, true)
+	WriteJSON(w, http.StatusCreated, map[string]interface{}{"webhook": resp})
+}
+
+func (h *WebhookHandler) GetWebhooksList(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.webhookService.List(r.Context())
+	if err != nil {
+		WriteInternalError(w, r, err)
+		return
+	}
+
+	resp := make([]api.WebhookSubscription, 0, len(subs))
+	for _, sub := range subs {
+		resp = append(resp, mapper.ToAPIWebhookSubscription(sub, false))
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"webhooks": resp})
+}
+
+func (h *WebhookHandler) PostWebhooksDelete(w http.ResponseWriter, r *http.Request) {
+	var body api.PostWebhooksDeleteJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid JSON")
+		return
+	}
+
+	webhookID := strings.TrimSpace(body.WebhookId)
+	if webhookID == "" {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "webhook_id must not be empty")
+		return
+	}
+
+	if err := h.webhookService.Unsubscribe(r.Context(), webhookID); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *WebhookHandler) GetWebhooksDeliveries(w http.ResponseWriter, r *http.Request, params api.GetWebhooksDeliveriesParams) {
+	webhookID := strings.TrimSpace(params.WebhookId)
+	if webhookID == "" {
+		WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "webhook_id must not be empty")
+		return
+	}
+
+	var status model.DeliveryStatus
+	if params.Status != nil {
+		status = model.DeliveryStatus(*params.Status)
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(r.Context(), webhookID, status)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]api.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, mapper.ToAPIWebhookDelivery(d))
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"webhook_id": webhookID,
+		"deliveries": resp,
+	})
+}
@@ -0,0 +1,45 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Heartbeat tracks the liveness of a background loop. The loop calls Start
+// before its first iteration, Beat after every iteration and Stop on exit;
+// Check fails once the loop stops or goes longer than stallAfter without a beat.
+type Heartbeat struct {
+	stallAfter time.Duration
+	running    atomic.Bool
+	lastBeat   atomic.Int64
+}
+
+func NewHeartbeat(stallAfter time.Duration) *Heartbeat {
+	return &Heartbeat{stallAfter: stallAfter}
+}
+
+func (h *Heartbeat) Start() {
+	h.Beat()
+	h.running.Store(true)
+}
+
+func (h *Heartbeat) Stop() {
+	h.running.Store(false)
+}
+
+func (h *Heartbeat) Beat() {
+	h.lastBeat.Store(time.Now().UnixNano())
+}
+
+func (h *Heartbeat) Check(context.Context) error {
+	if !h.running.Load() {
+		return errors.New("not running")
+	}
+	if since := time.Since(time.Unix(0, h.lastBeat.Load())); since > h.stallAfter {
+		return fmt.Errorf("made no progress for %s", since.Round(time.Second))
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package integration
+
+import "errors"
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+)
+
+type Action string
+
+const (
+	ActionOpened   Action = "opened"
+	ActionMerged   Action = "merged"
+	ActionClosed   Action = "closed"
+	ActionReopened Action = "reopened"
+	ActionReady    Action = "ready"
+	ActionDraft    Action = "draft"
+)
+
+// PullRequestEvent is a hosting pull/merge request event normalized across providers.
+// A nil event from a parser means the delivery carries nothing we act on.
+type PullRequestEvent struct {
+	Action Action
+	// PullRequestID is stable per hosted PR, e.g. "github:org/repo#12".
+	PullRequestID string
+	Name          string
+	// Author is the hosting username of the PR author. GitLab names the author
+	// by its numeric user id when someone else triggered the hook.
+	Author string
+	Draft  bool
+}
+
+// UserMap translates hosting usernames to users.id. Usernames without an entry
+// are used as ids unchanged.
+type UserMap map[string]string
+
+func (m UserMap) Resolve(username string) string {
+	if id, ok := m[username]; ok {
+		return id
+	}
+	return username
+}
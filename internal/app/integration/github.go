@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+	GitHubEventHeader     = "X-GitHub-Event"
+)
+
+type gitHubPullRequestPayload struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// VerifyGitHub checks the "sha256=<hex>" HMAC of body sent in X-Hub-Signature-256.
+func VerifyGitHub(header http.Header, body []byte, secret string) error {
+	sig, ok := strings.CutPrefix(header.Get(GitHubSignatureHeader), "sha256=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ParseGitHub maps a "pull_request" delivery to an event. Other events and
+// actions yield nil.
+func ParseGitHub(header http.Header, body []byte) (*PullRequestEvent, error) {
+	if header.Get(GitHubEventHeader) != "pull_request" {
+		return nil, nil
+	}
+
+	var p gitHubPullRequestPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, ErrInvalidPayload
+	}
+	if p.Repository.FullName == "" || p.Number == 0 {
+		return nil, ErrInvalidPayload
+	}
+
+	var action Action
+	switch p.Action {
+	case "opened":
+		action = ActionOpened
+	case "reopened":
+		action = ActionReopened
+	case "closed":
+		action = ActionClosed
+		if p.PullRequest.Merged {
+			action = ActionMerged
+		}
+	case "ready_for_review":
+		action = ActionReady
+	case "converted_to_draft":
+		action = ActionDraft
+	default:
+		return nil, nil
+	}
+
+	return &PullRequestEvent{
+		Action:        action,
+		PullRequestID: fmt.Sprintf("github:%s#%d", p.Repository.FullName, p.Number),
+		Name:          p.PullRequest.Title,
+		Author:        p.PullRequest.User.Login,
+		Draft:         p.PullRequest.Draft,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func gitHubEvent(event string) http.Header {
+	header := http.Header{}
+	header.Set(GitHubEventHeader, event)
+	return header
+}
+
+func TestParseGitHub(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    *PullRequestEvent
+	}{
+		{"github_opened_draft.json", &PullRequestEvent{Action: ActionOpened, PullRequestID: "github:acme/backend#42", Name: "Add reviewer fallback teams", Author: "octocat", Draft: true}},
+		{"github_ready_for_review.json", &PullRequestEvent{Action: ActionReady, PullRequestID: "github:acme/backend#42", Name: "Add reviewer fallback teams", Author: "octocat"}},
+		{"github_converted_to_draft.json", &PullRequestEvent{Action: ActionDraft, PullRequestID: "github:acme/backend#42", Name: "Add reviewer fallback teams", Author: "octocat", Draft: true}},
+		{"github_closed_merged.json", &PullRequestEvent{Action: ActionMerged, PullRequestID: "github:acme/backend#42", Name: "Add reviewer fallback teams", Author: "octocat"}},
+		{"github_labeled.json", nil},
+	}
+
+	header := gitHubEvent("pull_request")
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			got, err := ParseGitHub(header, readFixture(t, tt.fixture))
+			if err != nil {
+				t.Fatalf("ParseGitHub: %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ParseGitHub = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGitHubIgnoresOtherEvents(t *testing.T) {
+	header := gitHubEvent("push")
+	got, err := ParseGitHub(header, readFixture(t, "github_opened_draft.json"))
+	if err != nil || got != nil {
+		t.Errorf("ParseGitHub = %+v, %v, want nil, nil", got, err)
+	}
+}
+
+func TestParseGitHubInvalidPayload(t *testing.T) {
+	header := gitHubEvent("pull_request")
+	for _, body := range []string{`not json`, `{"action":"opened","number":1}`} {
+		if _, err := ParseGitHub(header, []byte(body)); !errors.Is(err, ErrInvalidPayload) {
+			t.Errorf("ParseGitHub(%s) error = %v, want ErrInvalidPayload", body, err)
+		}
+	}
+}
+
+func TestVerifyGitHub(t *testing.T) {
+	body := readFixture(t, "github_opened_draft.json")
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name      string
+		signature string
+		secret    string
+		wantErr   bool
+	}{
+		{"valid", signature, "secret", false},
+		{"wrong secret", signature, "other", true},
+		{"missing prefix", signature[len("sha256="):], "secret", true},
+		{"not hex", "sha256=zz", "secret", true},
+		{"missing", "", "secret", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.signature != "" {
+				header.Set(GitHubSignatureHeader, tt.signature)
+			}
+			err := VerifyGitHub(header, body, tt.secret)
+			if tt.wantErr != (err != nil) {
+				t.Errorf("VerifyGitHub error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
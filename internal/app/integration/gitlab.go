@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	GitLabTokenHeader = "X-Gitlab-Token"
+	GitLabEventHeader = "X-Gitlab-Event"
+)
+
+type gitLabMergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		ID       int    `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID      int    `json:"iid"`
+		Title    string `json:"title"`
+		Action   string `json:"action"`
+		Draft    bool   `json:"draft"`
+		AuthorID int    `json:"author_id"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Draft *struct {
+			Previous bool `json:"previous"`
+			Current  bool `json:"current"`
+		} `json:"draft"`
+	} `json:"changes"`
+}
+
+// author returns the username of the MR author when the author triggered the
+// hook. GitLab sends no username for the author otherwise, so its id is used.
+func (p *gitLabMergeRequestPayload) author() string {
+	if p.User.ID == p.ObjectAttributes.AuthorID {
+		return p.User.Username
+	}
+	return strconv.Itoa(p.ObjectAttributes.AuthorID)
+}
+
+// VerifyGitLab compares the secret token GitLab sends in X-Gitlab-Token.
+func VerifyGitLab(header http.Header, secret string) error {
+	if subtle.ConstantTimeCompare([]byte(header.Get(GitLabTokenHeader)), []byte(secret)) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ParseGitLab maps a "Merge Request Hook" delivery to an event. An "update"
+// is only of interest when it changes the draft flag.
+func ParseGitLab(header http.Header, body []byte) (*PullRequestEvent, error) {
+	if header.Get(GitLabEventHeader) != "Merge Request Hook" {
+		return nil, nil
+	}
+
+	var p gitLabMergeRequestPayload
+	if err := json.Unmarshal(body, &p); err != nil || p.ObjectKind != "merge_request" {
+		return nil, ErrInvalidPayload
+	}
+	if p.Project.PathWithNamespace == "" || p.ObjectAttributes.IID == 0 {
+		return nil, ErrInvalidPayload
+	}
+
+	var action Action
+	switch p.ObjectAttributes.Action {
+	case "open":
+		action = ActionOpened
+	case "reopen":
+		action = ActionReopened
+	case "merge":
+		action = ActionMerged
+	case "close":
+		action = ActionClosed
+	case "update":
+		draft := p.Changes.Draft
+		if draft == nil || draft.Previous == draft.Current {
+			return nil, nil
+		}
+		action = ActionReady
+		if draft.Current {
+			action = ActionDraft
+		}
+	default:
+		return nil, nil
+	}
+
+	return &PullRequestEvent{
+		Action:        action,
+		PullRequestID: fmt.Sprintf("gitlab:%s!%d", p.Project.PathWithNamespace, p.ObjectAttributes.IID),
+		Name:          p.ObjectAttributes.Title,
+		Author:        p.author(),
+		Draft:         p.ObjectAttributes.Draft,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package integration
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func gitLabEvent(event string) http.Header {
+	header := http.Header{}
+	header.Set(GitLabEventHeader, event)
+	return header
+}
+
+func gitLabToken(token string) http.Header {
+	header := http.Header{}
+	header.Set(GitLabTokenHeader, token)
+	return header
+}
+
+func TestParseGitLab(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    *PullRequestEvent
+	}{
+		{"gitlab_open.json", &PullRequestEvent{Action: ActionOpened, PullRequestID: "gitlab:acme/backend!7", Name: "Draft: Add reviewer fallback teams", Author: "jdoe", Draft: true}},
+		{"gitlab_update_ready.json", &PullRequestEvent{Action: ActionReady, PullRequestID: "gitlab:acme/backend!7", Name: "Add reviewer fallback teams", Author: "jdoe"}},
+		// Merged by a bot: the author is only known by its GitLab user id.
+		{"gitlab_merge_by_other.json", &PullRequestEvent{Action: ActionMerged, PullRequestID: "gitlab:acme/backend!7", Name: "Add reviewer fallback teams", Author: "17"}},
+		{"gitlab_update_title.json", nil},
+	}
+
+	header := gitLabEvent("Merge Request Hook")
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			got, err := ParseGitLab(header, readFixture(t, tt.fixture))
+			if err != nil {
+				t.Fatalf("ParseGitLab: %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ParseGitLab = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGitLabConvertedToDraft(t *testing.T) {
+	body := []byte(`{
+		"object_kind": "merge_request",
+		"user": {"id": 17, "username": "jdoe"},
+		"project": {"path_with_namespace": "acme/backend"},
+		"object_attributes": {"iid": 7, "title": "Draft: Add reviewer fallback teams", "author_id": 17, "draft": true, "action": "update"},
+		"changes": {"draft": {"previous": false, "current": true}}
+	}`)
+
+	got, err := ParseGitLab(gitLabEvent("Merge Request Hook"), body)
+	if err != nil {
+		t.Fatalf("ParseGitLab: %v", err)
+	}
+	if got == nil || got.Action != ActionDraft {
+		t.Errorf("ParseGitLab = %+v, want action %s", got, ActionDraft)
+	}
+}
+
+func TestParseGitLabInvalidPayload(t *testing.T) {
+	header := gitLabEvent("Merge Request Hook")
+	for _, body := range []string{`not json`, `{"object_kind":"push"}`, `{"object_kind":"merge_request","object_attributes":{"iid":1}}`} {
+		if _, err := ParseGitLab(header, []byte(body)); !errors.Is(err, ErrInvalidPayload) {
+			t.Errorf("ParseGitLab(%s) error = %v, want ErrInvalidPayload", body, err)
+		}
+	}
+}
+
+func TestVerifyGitLab(t *testing.T) {
+	if err := VerifyGitLab(gitLabToken("secret"), "secret"); err != nil {
+		t.Errorf("VerifyGitLab with the right token: %v", err)
+	}
+	if err := VerifyGitLab(gitLabToken("other"), "secret"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyGitLab with a wrong token = %v, want ErrInvalidSignature", err)
+	}
+}
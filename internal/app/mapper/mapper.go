@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"strings"
 	"test/internal/api"
 	"test/internal/domain/model"
 )
@@ -15,6 +16,7 @@ func ToAPIUser(u *model.User) api.User {
 		Username: u.Username,
 		TeamName: u.TeamName,
 		IsActive: u.IsActive,
+		Version:  u.Version,
 	}
 }
 
@@ -51,19 +53,40 @@ func ToAPIPullRequest(pr *model.PullRequest) api.PullRequest {
 		return api.PullRequest{}
 	}
 
-	status := api.PullRequestStatusOPEN
-	if pr.Status == model.StatusMerged {
-		status = api.PullRequestStatusMERGED
+	var fallbacks *[]api.FallbackReviewer
+	if len(pr.FallbackReviewers) > 0 {
+		list := make([]api.FallbackReviewer, 0, len(pr.FallbackReviewers))
+		for _, id := range pr.AssignedReviewers {
+			if team, ok := pr.FallbackReviewers[id]; ok {
+				list = append(list, api.FallbackReviewer{UserId: id, TeamName: team})
+			}
+		}
+		fallbacks = &list
+	}
+
+	reviews := make([]api.Review, 0, len(pr.Reviews))
+	for _, id := range pr.AssignedReviewers {
+		if r, ok := pr.Reviews[id]; ok {
+			reviews = append(reviews, api.Review{
+				ReviewerId: id,
+				Decision:   api.ReviewDecision(r.Decision),
+				DecidedAt:  r.DecidedAt,
+			})
+		}
 	}
 
 	return api.PullRequest{
-		PullRequestId:     pr.ID,
-		PullRequestName:   pr.Name,
-		AuthorId:          pr.AuthorID,
-		AssignedReviewers: pr.AssignedReviewers,
-		Status:            status,
-		CreatedAt:         &pr.CreatedAt,
-		MergedAt:          pr.MergedAt,
+		PullRequestId:       pr.ID,
+		PullRequestName:     pr.Name,
+		AuthorId:            pr.AuthorID,
+		AssignedReviewers:   pr.AssignedReviewers,
+		FallbackReviewers:   fallbacks,
+		Reviews:             &reviews,
+		Status:              api.PullRequestStatus(pr.Status),
+		CreatedAt:           &pr.CreatedAt,
+		MergedAt:            pr.MergedAt,
+		MergeOverrideReason: pr.MergeOverrideReason,
+		Version:             pr.Version,
 	}
 }
 
@@ -72,15 +95,148 @@ func ToAPIPullRequestShort(pr *model.PullRequest) api.PullRequestShort {
 		return api.PullRequestShort{}
 	}
 
-	status := api.PullRequestShortStatusOPEN
-	if pr.Status == model.StatusMerged {
-		status = api.PullRequestShortStatusMERGED
-	}
-
 	return api.PullRequestShort{
 		PullRequestId:   pr.ID,
 		PullRequestName: pr.Name,
 		AuthorId:        pr.AuthorID,
-		Status:          status,
+		Status:          api.PullRequestShortStatus(pr.Status),
+		Version:         pr.Version,
+	}
+}
+
+func ToAPITeamSettings(s *model.TeamSettings) api.TeamSettings {
+	if s == nil {
+		return api.TeamSettings{}
+	}
+
+	var strategy *api.TeamSettingsSelectionStrategy
+	if s.Strategy != "" {
+		v := api.TeamSettingsSelectionStrategy(s.Strategy)
+		strategy = &v
+	}
+
+	fallbackTeams := append([]string{}, s.FallbackTeams...)
+	minApprovals := s.MinApprovals
+
+	return api.TeamSettings{
+		TeamName:          s.TeamName,
+		ReviewersPerPr:    s.ReviewersPerPR,
+		SelectionStrategy: strategy,
+		AllowCrossTeam:    s.AllowCrossTeam,
+		FallbackTeams:     &fallbackTeams,
+		MinApprovals:      &minApprovals,
+	}
+}
+
+func FromAPITeamSettings(s api.TeamSettings) *model.TeamSettings {
+	settings := &model.TeamSettings{
+		TeamName:       s.TeamName,
+		ReviewersPerPR: s.ReviewersPerPr,
+		AllowCrossTeam: s.AllowCrossTeam,
+		FallbackTeams:  []string{},
+	}
+	if s.FallbackTeams != nil {
+		for _, t := range *s.FallbackTeams {
+			settings.FallbackTeams = append(settings.FallbackTeams, strings.TrimSpace(t))
+		}
+	}
+	if s.MinApprovals != nil {
+		settings.MinApprovals = *s.MinApprovals
+	}
+	if s.SelectionStrategy != nil {
+		settings.Strategy = model.SelectionStrategy(*s.SelectionStrategy)
+	}
+	return settings
+}
+
+func ToAPIPrEvent(e *model.PrEvent) api.PrEvent {
+	if e == nil {
+		return api.PrEvent{}
+	}
+
+	event := api.PrEvent{
+		EventId:       e.ID,
+		Type:          api.PrEventType(e.Type),
+		ReviewerId:    optionalString(e.ReviewerID),
+		OldReviewerId: optionalString(e.OldReviewerID),
+		Details:       optionalString(e.Details),
+		CreatedAt:     e.CreatedAt,
+	}
+	if e.FromStatus != "" {
+		from := api.PrEventFromStatus(e.FromStatus)
+		event.FromStatus = &from
+	}
+	if e.ToStatus != "" {
+		to := api.PrEventToStatus(e.ToStatus)
+		event.ToStatus = &to
+	}
+	return event
+}
+
+// ToAPIWebhookSubscription maps a subscription; the secret is included only when withSecret is set.
+func ToAPIWebhookSubscription(sub *model.WebhookSubscription, withSecret bool) api.WebhookSubscription {
+	if sub == nil {
+		return api.WebhookSubscription{}
+	}
+
+	eventTypes := sub.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	resp := api.WebhookSubscription{
+		WebhookId:  sub.ID,
+		Url:        sub.URL,
+		EventTypes: eventTypes,
+		CreatedAt:  sub.CreatedAt,
+	}
+	if withSecret {
+		resp.Secret = optionalString(sub.Secret)
+	}
+	return resp
+}
+
+func ToAPIWebhookDelivery(d *model.WebhookDelivery) api.WebhookDelivery {
+	if d == nil {
+		return api.WebhookDelivery{}
+	}
+
+	resp := api.WebhookDelivery{
+		DeliveryId:  d.ID,
+		EventId:     d.EventID,
+		EventType:   d.EventType,
+		Status:      api.WebhookDeliveryStatus(d.Status),
+		Attempts:    d.Attempts,
+		LastError:   optionalString(d.LastError),
+		DeliveredAt: d.DeliveredAt,
+	}
+	if d.Status == model.DeliveryPending {
+		next := d.NextAttemptAt
+		resp.NextAttemptAt = &next
+	}
+	return resp
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// ToAPIToken maps a token; plain is the token value, set only right after
+// the token was created.
+func ToAPIToken(t *model.APIToken, plain string) api.ApiToken {
+	if t == nil {
+		return api.ApiToken{}
+	}
+
+	return api.ApiToken{
+		TokenId:   t.ID,
+		Name:      t.Name,
+		Role:      api.ApiTokenRole(t.Role),
+		UserId:    optionalString(t.UserID),
+		Token:     optionalString(plain),
+		CreatedAt: t.CreatedAt,
 	}
 }
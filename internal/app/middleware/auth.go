@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"test/internal/api"
+	"test/internal/app/auth"
+	"test/internal/app/handler"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+// bearerScheme is the security scheme of openapi.yaml whose scopes list the
+// roles allowed to call an operation.
+const bearerScheme = "bearerAuth"
+
+// Auth authenticates requests to the operations of spec with the bearer token
+// of the Authorization header and checks the role of the caller against the
+// security requirement of the operation. Missing or unknown tokens are answered
+// with 401, a role that is not listed with 403. The caller is stored in the
+// request context for auth.FromContext.
+//
+// Operations whose security requirement does not use the bearer scheme, and
+// paths the spec does not describe, are passed through.
+func Auth(spec *openapi3.T, authenticator auth.Authenticator) (func(http.Handler) http.Handler, error) {
+	router, err := legacy.NewRouter(spec)
+	if err != nil {
+		return nil, fmt.Errorf("openapi router: %w", err)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, _, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			roles, ok := allowedRoles(spec, route.Operation)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok := bearerToken(r)
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				handler.WriteJSONError(w, http.StatusUnauthorized, api.UNAUTHORIZED, "missing bearer token")
+				return
+			}
+			principal, err := authenticator.Authenticate(r.Context(), token)
+			if err != nil {
+				handler.WriteInternalError(w, r, fmt.Errorf("authenticate: %w", err))
+				return
+			}
+			if principal == nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				handler.WriteJSONError(w, http.StatusUnauthorized, api.UNAUTHORIZED, "invalid bearer token")
+				return
+			}
+			if len(roles) > 0 && !slices.Contains(roles, string(principal.Role)) {
+				handler.WriteJSONError(w, http.StatusForbidden, api.FORBIDDEN,
+					fmt.Sprintf("this operation requires the %s role", strings.Join(roles, " or ")))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}, nil
+}
+
+// allowedRoles returns the roles that may call op, taken from its security
+// requirement or else from the one of the spec. An empty list allows every
+// role. ok is false when the operation can be called without a bearer token.
+func allowedRoles(spec *openapi3.T, op *openapi3.Operation) (roles []string, ok bool) {
+	requirements := spec.Security
+	if op.Security != nil {
+		requirements = *op.Security
+	}
+	if len(requirements) == 0 {
+		return nil, false
+	}
+
+	for _, requirement := range requirements {
+		scopes, ok := requirement[bearerScheme]
+		if !ok {
+			return nil, false
+		}
+		roles = append(roles, scopes...)
+	}
+	return roles, true
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// principalKey identifies the caller behind p: the user it acts for, or the
+// token when it acts for no user.
+func principalKey(p *auth.Principal) string {
+	if p.UserID != "" {
+		return "user:" + p.UserID
+	}
+	return "token:" + p.TokenID
+}
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"test/internal/api"
+	"test/internal/app/auth"
+	"test/internal/app/handler"
+	"test/internal/domain/model"
+	"test/internal/domain/repository"
+	"time"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentRequestBytes = 1 << 20
+)
+
+// Idempotency makes POST requests carrying an Idempotency-Key header safe to
+// retry. The first response with a status below 500 is stored for ttl and
+// replayed, with its Content-Type and ETag, for repeats with the same method, path and body. A repeat with a
+// different request is rejected with 422, a repeat that arrives while the
+// first request is still running with 409. A request that has not finished
+// within lease is taken for dead, so that a retry is not locked out for the
+// whole ttl after a crash. Keys are scoped to the caller, so one caller cannot
+// replay the responses of another. It must run after Auth.
+func Idempotency(repo repository.IdempotencyRepository, ttl, lease time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				handler.WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "Idempotency-Key must not be longer than 255 characters")
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentRequestBytes+1))
+			if err != nil {
+				handler.WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "invalid body")
+				return
+			}
+			if len(body) > maxIdempotentRequestBytes {
+				handler.WriteJSONError(w, http.StatusRequestEntityTooLarge, api.VALIDATIONERROR,
+					fmt.Sprintf("request body must not be larger than %d bytes", maxIdempotentRequestBytes))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			key = scopedKey(r, key)
+			rec := model.NewIdempotencyRecord(key, requestHash(r, body), ttl, lease)
+			existing, err := repo.Reserve(r.Context(), rec)
+			if err != nil {
+				handler.WriteInternalError(w, r, fmt.Errorf("idempotency: reserve key %q: %w", key, err))
+				return
+			}
+			if existing != nil {
+				replay(w, rec, existing)
+				return
+			}
+
+			// The outcome is stored even if the client goes away mid-request.
+			ctx := context.WithoutCancel(r.Context())
+			rw := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+			completed := false
+			defer func() {
+				if !completed {
+					if err := repo.Release(ctx, key); err != nil {
+						slog.ErrorContext(ctx, "idempotency: release key", "key", key, "error", err)
+					}
+				}
+			}()
+
+			next.ServeHTTP(rw, r)
+
+			if rw.status >= http.StatusInternalServerError {
+				return
+			}
+			rec.StatusCode = rw.status
+			rec.ContentType = rw.Header().Get("Content-Type")
+			rec.ETag = rw.Header().Get("ETag")
+			rec.Body = rw.body.Bytes()
+			if err := repo.Complete(ctx, rec); err != nil {
+				slog.ErrorContext(ctx, "idempotency: store response", "key", key, "error", err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+func replay(w http.ResponseWriter, rec, existing *model.IdempotencyRecord) {
+	if existing.RequestHash != rec.RequestHash {
+		handler.WriteJSONError(w, http.StatusUnprocessableEntity, api.IDEMPOTENCYKEYREUSED, "idempotency key was used with a different request")
+		return
+	}
+	if !existing.Completed() {
+		handler.WriteJSONError(w, http.StatusConflict, api.REQUESTINPROGRESS, "a request with this idempotency key is in progress")
+		return
+	}
+
+	if existing.ContentType != "" {
+		w.Header().Set("Content-Type", existing.ContentType)
+	}
+	if existing.ETag != "" {
+		w.Header().Set("ETag", existing.ETag)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(existing.StatusCode)
+	_, _ = w.Write(existing.Body)
+}
+
+// scopedKey prefixes key with the caller. Without authentication there is no
+// caller to tell apart and keys are shared.
+func scopedKey(r *http.Request, key string) string {
+	if p := auth.FromContext(r.Context()); p != nil {
+		return principalKey(p) + " " + key
+	}
+	return key
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordingWriter passes the response through while keeping a copy of it.
+type recordingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"test/internal/infrastructure/persistence/memory"
+)
+
+func TestIdempotencyReplaysETag(t *testing.T) {
+	calls := 0
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"1"`)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"pr":{"pull_request_id":"pr-1"}}`))
+	})
+	h := Idempotency(memory.NewIdempotencyRepository(memory.NewStore()), time.Hour, time.Minute)(next)
+
+	send := func() *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(`{"pull_request_id":"pr-1"}`))
+		r.Header.Set(IdempotencyKeyHeader, "key-1")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	first := send()
+	replayed := send()
+	if calls != 1 {
+		t.Fatalf("handler calls = %d, want 1", calls)
+	}
+	if replayed.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Fatalf("second response is not a replay")
+	}
+	if replayed.Code != first.Code || replayed.Body.String() != first.Body.String() {
+		t.Errorf("replay = %d %s, want %d %s", replayed.Code, replayed.Body, first.Code, first.Body)
+	}
+	for _, name := range []string{"Content-Type", "ETag"} {
+		if got, want := replayed.Header().Get(name), first.Header().Get(name); got != want {
+			t.Errorf("replayed %s = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"database/sql"
+	"net/http"
+	"sync"
+	"test/internal/api"
+	"test/internal/app/handler"
+	"time"
+)
+
+// shedRetryAfter is the Retry-After of shed requests. Shedding stops as soon
+// as requests no longer queue for connections, so clients may retry quickly.
+const shedRetryAfter = time.Second
+
+// poolSampleInterval is how often the stats of the pool are read. Requests in
+// between reuse the last verdict.
+const poolSampleInterval = 100 * time.Millisecond
+
+// LoadShedder answers API requests with 503 and a Retry-After header while the
+// database pool is saturated: every connection is in use and the requests that
+// waited for one during the last sampling interval waited maxWait or longer
+// on average. Rejecting the overflow early keeps it from queueing for
+// connections until every request times out. stats is usually (*sql.DB).Stats,
+// so the webhook dispatcher and the other users of the pool count as well.
+func LoadShedder(stats func() sql.DBStats, maxWait time.Duration) func(http.Handler) http.Handler {
+	pool := &poolMonitor{stats: stats, maxWait: maxWait}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if pool.saturated(time.Now()) {
+				w.Header().Set("Retry-After", retryAfter(shedRetryAfter))
+				handler.WriteJSONError(w, http.StatusServiceUnavailable, api.OVERLOADED, "service is overloaded, retry later")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// poolMonitor turns the cumulative wait counters of sql.DBStats into a
+// verdict about the last sampling interval.
+type poolMonitor struct {
+	stats   func() sql.DBStats
+	maxWait time.Duration
+
+	mu        sync.Mutex
+	sampledAt time.Time
+	last      sql.DBStats
+	verdict   bool
+}
+
+func (m *poolMonitor) saturated(now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if now.Sub(m.sampledAt) < poolSampleInterval {
+		return m.verdict
+	}
+	s := m.stats()
+	waits := s.WaitCount - m.last.WaitCount
+	waited := s.WaitDuration - m.last.WaitDuration
+	m.verdict = s.MaxOpenConnections > 0 && s.InUse >= s.MaxOpenConnections &&
+		waits > 0 && waited >= time.Duration(waits)*m.maxWait
+	m.last, m.sampledAt = s, now
+	return m.verdict
+}
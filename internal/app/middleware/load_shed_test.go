@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPoolMonitor(t *testing.T) {
+	tests := []struct {
+		name string
+		next sql.DBStats
+		want bool
+	}{
+		{"free connections", sql.DBStats{MaxOpenConnections: 4, InUse: 3, WaitCount: 10, WaitDuration: 10 * time.Second}, false},
+		{"all in use, nobody waited", sql.DBStats{MaxOpenConnections: 4, InUse: 4}, false},
+		{"all in use, short waits", sql.DBStats{MaxOpenConnections: 4, InUse: 4, WaitCount: 10, WaitDuration: 10 * time.Millisecond}, false},
+		{"all in use, long waits", sql.DBStats{MaxOpenConnections: 4, InUse: 4, WaitCount: 10, WaitDuration: 2 * time.Second}, true},
+		{"unlimited pool", sql.DBStats{InUse: 40, WaitCount: 10, WaitDuration: 2 * time.Second}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := sql.DBStats{}
+			m := &poolMonitor{stats: func() sql.DBStats { return stats }, maxWait: 100 * time.Millisecond}
+			start := time.Now()
+			if m.saturated(start) {
+				t.Fatal("saturated before any wait")
+			}
+
+			stats = tt.next
+			if got := m.saturated(start.Add(poolSampleInterval)); got != tt.want {
+				t.Errorf("saturated = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoolMonitorLooksAtLastInterval(t *testing.T) {
+	stats := sql.DBStats{MaxOpenConnections: 4, InUse: 4, WaitCount: 10, WaitDuration: 2 * time.Second}
+	m := &poolMonitor{stats: func() sql.DBStats { return stats }, maxWait: 100 * time.Millisecond}
+	start := time.Now()
+
+	if !m.saturated(start) {
+		t.Fatal("saturated = false, want true")
+	}
+	// No new waits: the earlier ones no longer count.
+	if m.saturated(start.Add(poolSampleInterval)) {
+		t.Error("saturated = true without new waits, want false")
+	}
+}
+
+func TestLoadShedder(t *testing.T) {
+	stats := sql.DBStats{MaxOpenConnections: 1, InUse: 1, WaitCount: 1, WaitDuration: time.Second}
+	h := LoadShedder(func() sql.DBStats { return stats }, 100*time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/team/get", nil))
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "1" {
+		t.Errorf("saturated pool: status %d, Retry-After %q, want 503 and 1", w.Code, w.Header().Get("Retry-After"))
+	}
+}
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"test/internal/api"
+	"test/internal/app/auth"
+	"test/internal/app/handler"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimit is a token bucket: RPS tokens per second are added up to Burst,
+// and every request takes one.
+type RateLimit struct {
+	RPS   float64
+	Burst int
+}
+
+// RateLimiter gives every client a bucket per route group and answers requests
+// that find their bucket empty with 429 and a Retry-After header. A client is
+// the authenticated user or token, or else the client IP, so it must run after
+// Auth. Route groups are path prefixes with their own limits; the longest
+// matching prefix wins, and other paths share the default group.
+func RateLimiter(def RateLimit, groups map[string]RateLimit) func(http.Handler) http.Handler {
+	buckets := newBuckets()
+
+	prefixes := make([]string, 0, len(groups))
+	for prefix := range groups {
+		prefixes = append(prefixes, prefix)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			group, limit := "", def
+			for _, prefix := range prefixes {
+				if strings.HasPrefix(r.URL.Path, prefix) && len(prefix) > len(group) {
+					group, limit = prefix, groups[prefix]
+				}
+			}
+
+			if !allow(w, buckets.get(bucketKey{group: group, client: clientKey(r)}, limit)) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// IPRateLimiter gives every client IP one bucket for all routes. It runs
+// before Auth, so that requests with a missing or invalid token, which never
+// reach RateLimiter, cannot cost a token lookup each without limit.
+func IPRateLimiter(limit RateLimit) func(http.Handler) http.Handler {
+	buckets := newBuckets()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !allow(w, buckets.get(bucketKey{client: ipKey(r)}, limit)) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// allow takes a token from limiter, or answers 429 when there is none.
+func allow(w http.ResponseWriter, limiter *rate.Limiter) bool {
+	reservation := limiter.Reserve()
+	if !reservation.OK() {
+		handler.WriteJSONError(w, http.StatusTooManyRequests, api.RATELIMITED, "rate limit exceeded")
+		return false
+	}
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		w.Header().Set("Retry-After", retryAfter(delay))
+		handler.WriteJSONError(w, http.StatusTooManyRequests, api.RATELIMITED, "rate limit exceeded")
+		return false
+	}
+	return true
+}
+
+// clientKey identifies the client of r for rate limiting.
+func clientKey(r *http.Request) string {
+	if p := auth.FromContext(r.Context()); p != nil {
+		return principalKey(p)
+	}
+	return ipKey(r)
+}
+
+func ipKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// retryAfter renders d as the whole seconds of a Retry-After header.
+func retryAfter(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+type bucketKey struct {
+	group  string
+	client string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+	// idle is how long the bucket takes to refill, after which it can be
+	// dropped and recreated full.
+	idle time.Duration
+}
+
+// buckets holds the bucket of every client seen recently.
+type buckets struct {
+	mu       sync.Mutex
+	limiters map[bucketKey]*bucket
+	sweptAt  time.Time
+}
+
+func newBuckets() *buckets {
+	return &buckets{limiters: make(map[bucketKey]*bucket)}
+}
+
+// sweepInterval is how often buckets that have refilled are dropped.
+const sweepInterval = time.Minute
+
+func (b *buckets) get(key bucketKey, limit RateLimit) *rate.Limiter {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(b.sweptAt) >= sweepInterval {
+		for k, bkt := range b.limiters {
+			if now.Sub(bkt.lastSeen) > bkt.idle {
+				delete(b.limiters, k)
+			}
+		}
+		b.sweptAt = now
+	}
+
+	bkt, ok := b.limiters[key]
+	if !ok {
+		bkt = &bucket{
+			limiter: rate.NewLimiter(rate.Limit(limit.RPS), limit.Burst),
+			idle:    time.Duration(float64(limit.Burst) / limit.RPS * float64(time.Second)),
+		}
+		b.limiters[key] = bkt
+	}
+	bkt.lastSeen = now
+	return bkt.limiter
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"test/internal/api"
+	"test/internal/app/handler"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+// Validation checks requests to the operations of spec against it before they
+// reach the handlers. Bodies larger than maxBodyBytes are rejected with 413 and
+// other violations with 400, both as an ErrorResponse with VALIDATION_ERROR.
+// Requests to paths the spec does not describe are passed through.
+//
+// With validateResponses the responses are checked too and every mismatch is
+// logged. This buffers each response and is meant for development.
+func Validation(spec *openapi3.T, maxBodyBytes int64, validateResponses bool) (func(http.Handler) http.Handler, error) {
+	router, err := legacy.NewRouter(spec)
+	if err != nil {
+		return nil, fmt.Errorf("openapi router: %w", err)
+	}
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+			if err != nil {
+				handler.WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, "could not read request body")
+				return
+			}
+			if int64(len(body)) > maxBodyBytes {
+				handler.WriteJSONError(w, http.StatusRequestEntityTooLarge, api.VALIDATIONERROR,
+					fmt.Sprintf("request body must not be larger than %d bytes", maxBodyBytes))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				handler.WriteJSONError(w, http.StatusBadRequest, api.VALIDATIONERROR, validationMessage(err))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			if !validateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rw := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r)
+
+			err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 rw.status,
+				Header:                 rw.Header(),
+				Body:                   io.NopCloser(bytes.NewReader(rw.body.Bytes())),
+				Options:                options,
+			})
+			if err != nil {
+				slog.ErrorContext(r.Context(), "response does not match openapi spec",
+					"method", r.Method, "path", route.Path, "status", rw.status, "error", validationMessage(err))
+			}
+		})
+	}, nil
+}
+
+// validationMessage turns a kin-openapi error into a single line naming the
+// offending parameter or body field.
+func validationMessage(err error) string {
+	var what string
+	var reqErr *openapi3filter.RequestError
+	var respErr *openapi3filter.ResponseError
+	switch {
+	case errors.As(err, &reqErr):
+		what = "request body"
+		if reqErr.Parameter != nil {
+			what = fmt.Sprintf("%s parameter %q", reqErr.Parameter.In, reqErr.Parameter.Name)
+		}
+		err = reqErr.Err
+		if err == nil {
+			return what + ": " + reqErr.Reason
+		}
+	case errors.As(err, &respErr):
+		what = "response body"
+		err = respErr.Err
+		if err == nil {
+			return what + ": " + respErr.Reason
+		}
+	default:
+		return err.Error()
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		if path := schemaErr.JSONPointer(); len(path) > 0 {
+			what += " field " + strings.Join(path, ".")
+		}
+		return what + ": " + schemaErr.Reason
+	}
+	return what + ": " + err.Error()
+}
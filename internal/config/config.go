@@ -0,0 +1,377 @@
+// Package config loads the service configuration. Values start from Default,
+// are overridden by the YAML file named by CONFIG_FILE and then by environment
+// variables, and are checked by Validate.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"test/internal/domain/model"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	// Storage is "postgres" or "memory".
+	Storage      string             `yaml:"storage" env:"STORAGE"`
+	Server       ServerConfig       `yaml:"server"`
+	Database     DatabaseConfig     `yaml:"database"`
+	Reviewers    ReviewersConfig    `yaml:"reviewers"`
+	Idempotency  IdempotencyConfig  `yaml:"idempotency"`
+	Validation   ValidationConfig   `yaml:"validation"`
+	Auth         AuthConfig         `yaml:"auth"`
+	RateLimit    RateLimitConfig    `yaml:"rate_limit"`
+	LoadShedding LoadSheddingConfig `yaml:"load_shedding"`
+	Integrations IntegrationsConfig `yaml:"integrations"`
+	Features     FeaturesConfig     `yaml:"features"`
+	Log          LogConfig          `yaml:"log"`
+	Tracing      TracingConfig      `yaml:"tracing"`
+}
+
+type ServerConfig struct {
+	Host              string        `yaml:"host" env:"HOSTNAME"`
+	Port              int           `yaml:"port" env:"PORT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	// ShutdownDelay is how long the server keeps accepting requests after
+	// SIGTERM while /readyz already fails, so load balancers can notice it.
+	ShutdownDelay time.Duration `yaml:"shutdown_delay" env:"SHUTDOWN_DELAY"`
+	// ShutdownTimeout bounds how long in-flight requests may run after SIGTERM.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+}
+
+// Addr is the listen address of the HTTP server.
+func (c ServerConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
+
+type DatabaseConfig struct {
+	URL             string        `yaml:"url" env:"DATABASE_URL"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"`
+	MigrateOnStart  bool          `yaml:"migrate_on_start" env:"MIGRATE_ON_START"`
+}
+
+type ReviewersConfig struct {
+	// Strategy is the default reviewer selection strategy.
+	Strategy string `yaml:"strategy" env:"REVIEWER_STRATEGY"`
+	// TeamStrategies overrides Strategy per team.
+	TeamStrategies map[string]string `yaml:"team_strategies" env:"REVIEWER_TEAM_STRATEGIES"`
+	// Weights configures the weighted strategy per user.
+	Weights map[string]int `yaml:"weights" env:"REVIEWER_WEIGHTS"`
+}
+
+type IdempotencyConfig struct {
+	TTL time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL"`
+	// Lease is how long a request in progress holds its key. It should exceed
+	// the longest request; a retry after it takes over the key of a request
+	// that died without releasing it.
+	Lease time.Duration `yaml:"lease" env:"IDEMPOTENCY_LEASE"`
+}
+
+type ValidationConfig struct {
+	// MaxBodyBytes is the largest request body accepted by the API.
+	MaxBodyBytes int `yaml:"max_body_bytes" env:"MAX_BODY_BYTES"`
+	// Responses also checks responses against the spec and logs mismatches.
+	// It buffers every response, so it is meant for development.
+	Responses bool `yaml:"responses" env:"VALIDATE_RESPONSES"`
+}
+
+type AuthConfig struct {
+	// AdminToken is a static ADMIN token, used to issue the first tokens
+	// through /admin/tokens/create. Empty disables it.
+	AdminToken string `yaml:"admin_token" env:"AUTH_ADMIN_TOKEN"`
+	// JWKSFile or JWKSURL enable JWTs of the identity provider, verified with
+	// the key set read from the file or fetched from the URL.
+	JWKSFile string `yaml:"jwks_file" env:"AUTH_JWKS_FILE"`
+	JWKSURL  string `yaml:"jwks_url" env:"AUTH_JWKS_URL"`
+	// JWKSRefresh is how long the key set is cached. Tokens signed with an
+	// unknown key reload it earlier.
+	JWKSRefresh time.Duration `yaml:"jwks_refresh" env:"AUTH_JWKS_REFRESH"`
+	// JWTIssuer and JWTAudience, when set, must match the iss and aud claims.
+	JWTIssuer   string `yaml:"jwt_issuer" env:"AUTH_JWT_ISSUER"`
+	JWTAudience string `yaml:"jwt_audience" env:"AUTH_JWT_AUDIENCE"`
+	// JWTUserClaim holds the users.id of the caller, JWTRoleClaim its role.
+	// Dotted names select nested claims.
+	JWTUserClaim string `yaml:"jwt_user_claim" env:"AUTH_JWT_USER_CLAIM"`
+	JWTRoleClaim string `yaml:"jwt_role_claim" env:"AUTH_JWT_ROLE_CLAIM"`
+	// JWTRoles maps values of the role claim to ADMIN or USER.
+	JWTRoles map[string]string `yaml:"jwt_roles" env:"AUTH_JWT_ROLES"`
+}
+
+// RateLimitConfig configures the token bucket of every client per route
+// group. A client is the authenticated user or token, or else the client IP.
+type RateLimitConfig struct {
+	// RPS and Burst are the limits of the default group.
+	RPS   float64 `yaml:"rps" env:"RATE_LIMIT_RPS"`
+	Burst int     `yaml:"burst" env:"RATE_LIMIT_BURST"`
+	// GroupRPS and GroupBurst override the limits for path prefixes such as
+	// "/pullRequest". A group missing from one of them uses the default.
+	GroupRPS   map[string]float64 `yaml:"group_rps" env:"RATE_LIMIT_GROUP_RPS"`
+	GroupBurst map[string]int     `yaml:"group_burst" env:"RATE_LIMIT_GROUP_BURST"`
+	// IPRPS and IPBurst limit every client IP before authentication, which
+	// also covers requests without a valid token.
+	IPRPS   float64 `yaml:"ip_rps" env:"RATE_LIMIT_IP_RPS"`
+	IPBurst int     `yaml:"ip_burst" env:"RATE_LIMIT_IP_BURST"`
+}
+
+type LoadSheddingConfig struct {
+	// MaxWait is the average wait for a database connection above which API
+	// requests are shed while every connection of the pool is in use.
+	MaxWait time.Duration `yaml:"max_wait" env:"LOAD_SHED_MAX_WAIT"`
+}
+
+type IntegrationsConfig struct {
+	GitHubWebhookSecret string `yaml:"github_webhook_secret" env:"GITHUB_WEBHOOK_SECRET"`
+	GitLabWebhookToken  string `yaml:"gitlab_webhook_token" env:"GITLAB_WEBHOOK_TOKEN"`
+	// UserMap maps hosting logins to user ids.
+	UserMap map[string]string `yaml:"user_map" env:"INTEGRATION_USER_MAP"`
+}
+
+// FeaturesConfig switches optional parts of the service on and off.
+type FeaturesConfig struct {
+	// Webhooks runs the outbox dispatcher that delivers events to subscriptions.
+	Webhooks bool `yaml:"webhooks" env:"FEATURE_WEBHOOKS"`
+	// Integrations mounts the GitHub and GitLab webhook endpoints whose secret is set.
+	Integrations bool `yaml:"integrations" env:"FEATURE_INTEGRATIONS"`
+	// Idempotency honours the Idempotency-Key header.
+	Idempotency bool `yaml:"idempotency" env:"FEATURE_IDEMPOTENCY"`
+	// Validation checks API requests against openapi.yaml before the handlers.
+	Validation bool `yaml:"validation" env:"FEATURE_VALIDATION"`
+	// Auth requires a bearer token with a permitted role for API requests.
+	Auth bool `yaml:"auth" env:"FEATURE_AUTH"`
+	// RateLimit answers clients over their rate limit with 429.
+	RateLimit bool `yaml:"rate_limit" env:"FEATURE_RATE_LIMIT"`
+	// LoadShedding answers API requests with 503 while the database pool is saturated.
+	LoadShedding bool `yaml:"load_shedding" env:"FEATURE_LOAD_SHEDDING"`
+	// Swagger serves the API spec and Swagger UI under /swagger.
+	Swagger bool `yaml:"swagger" env:"FEATURE_SWAGGER"`
+	// Metrics serves Prometheus metrics under /metrics.
+	Metrics bool `yaml:"metrics" env:"FEATURE_METRICS"`
+}
+
+type LogConfig struct {
+	// Level is one of debug, info, warn and error.
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+}
+
+type TracingConfig struct {
+	// Exporter is one of none, stdout, otlp-file and otlp-http.
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER"`
+	// Endpoint is the collector URL for otlp-http. When empty the exporter
+	// falls back to OTEL_EXPORTER_OTLP_ENDPOINT or http://localhost:4318.
+	Endpoint string `yaml:"endpoint" env:"TRACING_ENDPOINT"`
+	// File is where stdout and otlp-file write spans. stdout defaults to the
+	// process output, otlp-file requires a path.
+	File string `yaml:"file" env:"TRACING_FILE"`
+	// SampleRatio is the share of new traces that are recorded, from 0 to 1.
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+	// ServiceName is reported as the service.name resource attribute.
+	ServiceName string `yaml:"service_name" env:"TRACING_SERVICE_NAME"`
+}
+
+func Default() Config {
+	return Config{
+		Storage: "postgres",
+		Server: ServerConfig{
+			Host:              "0.0.0.0",
+			Port:              8080,
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownDelay:     5 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		Reviewers: ReviewersConfig{
+			Strategy: string(model.StrategyLeastLoaded),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:   24 * time.Hour,
+			Lease: time.Minute,
+		},
+		Validation: ValidationConfig{
+			MaxBodyBytes: 1 << 20,
+		},
+		Auth: AuthConfig{
+			JWKSRefresh:  5 * time.Minute,
+			JWTUserClaim: "sub",
+			JWTRoleClaim: "role",
+		},
+		RateLimit: RateLimitConfig{
+			RPS:     10,
+			Burst:   20,
+			IPRPS:   50,
+			IPBurst: 100,
+		},
+		LoadShedding: LoadSheddingConfig{
+			MaxWait: 100 * time.Millisecond,
+		},
+		Features: FeaturesConfig{
+			Webhooks:     true,
+			Integrations: true,
+			Idempotency:  true,
+			Validation:   true,
+			Auth:         true,
+			RateLimit:    true,
+			LoadShedding: true,
+			Swagger:      true,
+			Metrics:      true,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			SampleRatio: 1,
+			ServiceName: "pr-service",
+		},
+	}
+}
+
+// Load builds the configuration from defaults, the optional CONFIG_FILE and the
+// environment, and validates it.
+func Load() (Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("read config file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("parse config file %s: %w", path, err)
+		}
+	}
+
+	if err := applyEnv(&cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// Validate reports every invalid setting at once.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Storage == "postgres" || c.Storage == "memory", "storage: unknown storage %q", c.Storage)
+	if c.Storage == "postgres" {
+		check(c.Database.URL != "", "database.url: DATABASE_URL is not set")
+	}
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns: must not be negative")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns: must not be negative")
+	check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime: must not be negative")
+	check(c.Database.ConnMaxIdleTime >= 0, "database.conn_max_idle_time: must not be negative")
+
+	check(c.Server.Port > 0 && c.Server.Port < 65536, "server.port: %d is out of range", c.Server.Port)
+	check(c.Server.ReadTimeout >= 0, "server.read_timeout: must not be negative")
+	check(c.Server.ReadHeaderTimeout >= 0, "server.read_header_timeout: must not be negative")
+	check(c.Server.WriteTimeout >= 0, "server.write_timeout: must not be negative")
+	check(c.Server.IdleTimeout >= 0, "server.idle_timeout: must not be negative")
+	check(c.Server.ShutdownDelay >= 0, "server.shutdown_delay: must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout: must be positive")
+
+	check(model.SelectionStrategy(c.Reviewers.Strategy).IsValid(), "reviewers.strategy: unknown reviewer strategy %q", c.Reviewers.Strategy)
+	for team, strategy := range c.Reviewers.TeamStrategies {
+		check(model.SelectionStrategy(strategy).IsValid(), "reviewers.team_strategies: unknown reviewer strategy %q for team %s", strategy, team)
+	}
+	for userID, w := range c.Reviewers.Weights {
+		check(w > 0, "reviewers.weights: invalid weight %d for user %s", w, userID)
+	}
+
+	check(c.Idempotency.TTL > 0, "idempotency.ttl: must be positive")
+	check(c.Idempotency.Lease > 0, "idempotency.lease: must be positive")
+	check(c.Validation.MaxBodyBytes > 0, "validation.max_body_bytes: must be positive")
+	check(c.Auth.AdminToken == "" || len(c.Auth.AdminToken) >= 32, "auth.admin_token: must be at least 32 characters")
+	check(c.Auth.JWKSFile == "" || c.Auth.JWKSURL == "", "auth.jwks_file: AUTH_JWKS_FILE and AUTH_JWKS_URL are mutually exclusive")
+	if c.Auth.JWKSURL != "" {
+		u, err := url.Parse(c.Auth.JWKSURL)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", "auth.jwks_url: %q is not an absolute http(s) URL", c.Auth.JWKSURL)
+	}
+	check(c.Auth.JWKSRefresh > 0, "auth.jwks_refresh: must be positive")
+	check(c.Auth.JWTUserClaim != "", "auth.jwt_user_claim: must not be empty")
+	check(c.Auth.JWTRoleClaim != "", "auth.jwt_role_claim: must not be empty")
+	for value, role := range c.Auth.JWTRoles {
+		check(model.Role(role).IsValid(), "auth.jwt_roles: unknown role %q for %s", role, value)
+	}
+
+	check(c.RateLimit.RPS > 0, "rate_limit.rps: must be positive")
+	check(c.RateLimit.Burst > 0, "rate_limit.burst: must be positive")
+	for prefix, rps := range c.RateLimit.GroupRPS {
+		check(strings.HasPrefix(prefix, "/"), "rate_limit.group_rps: group %q is not a path prefix", prefix)
+		check(rps > 0, "rate_limit.group_rps: invalid rps %v for group %s", rps, prefix)
+	}
+	for prefix, burst := range c.RateLimit.GroupBurst {
+		check(strings.HasPrefix(prefix, "/"), "rate_limit.group_burst: group %q is not a path prefix", prefix)
+		check(burst > 0, "rate_limit.group_burst: invalid burst %d for group %s", burst, prefix)
+	}
+	check(c.RateLimit.IPRPS > 0, "rate_limit.ip_rps: must be positive")
+	check(c.RateLimit.IPBurst > 0, "rate_limit.ip_burst: must be positive")
+	check(c.LoadShedding.MaxWait >= 0, "load_shedding.max_wait: must not be negative")
+
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		check(false, "log.level: unknown level %q", c.Log.Level)
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp-http":
+	case "otlp-file":
+		check(c.Tracing.File != "", "tracing.file: TRACING_FILE is required by the otlp-file exporter")
+	default:
+		check(false, "tracing.exporter: unknown exporter %q", c.Tracing.Exporter)
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio: %v is not between 0 and 1", c.Tracing.SampleRatio)
+	check(c.Tracing.ServiceName != "", "tracing.service_name: must not be empty")
+
+	return errors.Join(errs...)
+}
+
+// Redacted returns a copy of c that is safe to print: secrets are masked and
+// the database password is removed from the URL.
+func (c Config) Redacted() Config {
+	const mask = "******"
+
+	if c.Database.URL != "" {
+		if u, err := url.Parse(c.Database.URL); err == nil {
+			c.Database.URL = u.Redacted()
+		} else {
+			c.Database.URL = mask
+		}
+	}
+	if c.Auth.AdminToken != "" {
+		c.Auth.AdminToken = mask
+	}
+	if c.Integrations.GitHubWebhookSecret != "" {
+		c.Integrations.GitHubWebhookSecret = mask
+	}
+	if c.Integrations.GitLabWebhookToken != "" {
+		c.Integrations.GitLabWebhookToken = mask
+	}
+	return c
+}
+
+// YAML renders c in the format of the config file.
+func (c Config) YAML() ([]byte, error) {
+	return yaml.Marshal(c)
+}
@@ -1,15 +1,90 @@
 package domain_errors
 
-import "errors"
+// Code identifies a domain error independently of its message, so callers can
+// translate it, for example into an HTTP status, without matching text.
+type Code string
+
+const (
+	CodeUserNotFound            Code = "USER_NOT_FOUND"
+	CodeTeamNotFound            Code = "TEAM_NOT_FOUND"
+	CodePullRequestNotFound     Code = "PULL_REQUEST_NOT_FOUND"
+	CodePullRequestExists       Code = "PULL_REQUEST_EXISTS"
+	CodeTeamExists              Code = "TEAM_EXISTS"
+	CodePRMerged                Code = "PR_MERGED"
+	CodeReviewerNotAssigned     Code = "REVIEWER_NOT_ASSIGNED"
+	CodeNoReplacementCandidate  Code = "NO_REPLACEMENT_CANDIDATE"
+	CodeUserHasOpenPullRequests Code = "USER_HAS_OPEN_PULL_REQUESTS"
+	CodeInvalidTeamSettings     Code = "INVALID_TEAM_SETTINGS"
+	CodeFallbackTeamNotFound    Code = "FALLBACK_TEAM_NOT_FOUND"
+	CodeInvalidStatusTransition Code = "INVALID_STATUS_TRANSITION"
+	CodePRNotOpen               Code = "PR_NOT_OPEN"
+	CodeInvalidReviewDecision   Code = "INVALID_REVIEW_DECISION"
+	CodeNotEnoughApprovals      Code = "NOT_ENOUGH_APPROVALS"
+	CodeWebhookNotFound         Code = "WEBHOOK_NOT_FOUND"
+	CodeInvalidWebhook          Code = "INVALID_WEBHOOK"
+	CodeVersionMismatch         Code = "VERSION_MISMATCH"
+	CodeTokenNotFound           Code = "TOKEN_NOT_FOUND"
+	CodeInvalidToken            Code = "INVALID_TOKEN"
+)
 
 var (
-	ErrUserNotFound            = errors.New("user not found")
-	ErrTeamNotFound            = errors.New("team not found")
-	ErrPullRequestNotFound     = errors.New("pull request not found")
-	ErrPullRequestExists       = errors.New("pull request already exists")
-	ErrTeamExists              = errors.New("team already exists")
-	ErrPRMerged                = errors.New("pull request already merged")
-	ErrReviewerNotAssigned     = errors.New("reviewer is not assigned to pull request")
-	ErrNoReplacementCandidate  = errors.New("no active candidate available")
-	ErrUserHasOpenPullRequests = errors.New("user has open pull requests")
+	ErrUserNotFound            = New(CodeUserNotFound, "user not found")
+	ErrTeamNotFound            = New(CodeTeamNotFound, "team not found")
+	ErrPullRequestNotFound     = New(CodePullRequestNotFound, "pull request not found")
+	ErrPullRequestExists       = New(CodePullRequestExists, "pull request already exists")
+	ErrTeamExists              = New(CodeTeamExists, "team already exists")
+	ErrPRMerged                = New(CodePRMerged, "pull request already merged")
+	ErrReviewerNotAssigned     = New(CodeReviewerNotAssigned, "reviewer is not assigned to pull request")
+	ErrNoReplacementCandidate  = New(CodeNoReplacementCandidate, "no active candidate available")
+	ErrUserHasOpenPullRequests = New(CodeUserHasOpenPullRequests, "user has open pull requests")
+	ErrInvalidTeamSettings     = New(CodeInvalidTeamSettings, "invalid team settings")
+	ErrFallbackTeamNotFound    = New(CodeFallbackTeamNotFound, "fallback team not found")
+	ErrInvalidStatusTransition = New(CodeInvalidStatusTransition, "pull request status transition is not allowed")
+	ErrPRNotOpen               = New(CodePRNotOpen, "pull request is not open")
+	ErrInvalidReviewDecision   = New(CodeInvalidReviewDecision, "invalid review decision")
+	ErrNotEnoughApprovals      = New(CodeNotEnoughApprovals, "pull request does not have enough approvals")
+	ErrWebhookNotFound         = New(CodeWebhookNotFound, "webhook subscription not found")
+	ErrInvalidWebhook          = New(CodeInvalidWebhook, "invalid webhook subscription")
+	ErrVersionMismatch         = New(CodeVersionMismatch, "resource version does not match")
+	ErrTokenNotFound           = New(CodeTokenNotFound, "api token not found")
+	ErrInvalidToken            = New(CodeInvalidToken, "invalid api token")
 )
+
+// Error is a domain error. The Err* values are the sentinels; With derives an
+// error carrying details that still matches its sentinel under errors.Is.
+type Error struct {
+	Code    Code
+	Message string
+	// Details name the entities involved, for example "user_id".
+	Details map[string]string
+
+	sentinel *Error
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether e was derived from target.
+func (e *Error) Is(target error) bool {
+	return e.sentinel != nil && e.sentinel == target
+}
+
+// With returns a copy of e with the detail key set to value.
+func (e *Error) With(key, value string) *Error {
+	details := make(map[string]string, len(e.Details)+1)
+	for k, v := range e.Details {
+		details[k] = v
+	}
+	details[key] = value
+
+	sentinel := e.sentinel
+	if sentinel == nil {
+		sentinel = e
+	}
+	return &Error{Code: e.Code, Message: e.Message, Details: details, sentinel: sentinel}
+}
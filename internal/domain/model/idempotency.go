@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// IdempotencyRecord is the stored outcome of a request sent with an Idempotency-Key.
+// A record without a status code belongs to a request that is still in progress.
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string
+	StatusCode  int
+	ContentType string
+	ETag        string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	// LockedUntil ends the reservation of a request in progress. A request that
+	// has not completed by then is taken for dead, for example because the
+	// process was killed, and a retry may take the key over.
+	LockedUntil time.Time
+}
+
+func NewIdempotencyRecord(key, requestHash string, ttl, lease time.Duration) *IdempotencyRecord {
+	now := time.Now()
+	return &IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+		LockedUntil: now.Add(lease),
+	}
+}
+
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// Available reports whether a new request may take the key of r over: r has
+// expired, or its request never completed and its lease has run out.
+func (r *IdempotencyRecord) Available(now time.Time) bool {
+	return !r.ExpiresAt.After(now) || (!r.Completed() && !r.LockedUntil.After(now))
+}
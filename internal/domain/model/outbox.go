@@ -0,0 +1,104 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxMessage is a domain event serialized for webhook subscribers. The
+// payload is the JSON body delivered to them.
+type OutboxMessage struct {
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	Payload       []byte
+	CreatedAt     time.Time
+}
+
+type outboxPayload struct {
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+type prEventPayload struct {
+	PullRequest   prPayload `json:"pull_request"`
+	ReviewerID    string    `json:"reviewer_id,omitempty"`
+	OldReviewerID string    `json:"old_reviewer_id,omitempty"`
+	FromStatus    string    `json:"from_status,omitempty"`
+	ToStatus      string    `json:"to_status,omitempty"`
+	Details       string    `json:"details,omitempty"`
+}
+
+type prPayload struct {
+	ID                string   `json:"pull_request_id"`
+	Name              string   `json:"pull_request_name"`
+	AuthorID          string   `json:"author_id"`
+	Status            string   `json:"status"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+}
+
+type userEventPayload struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	IsActive bool   `json:"is_active"`
+}
+
+func NewPrOutboxMessage(pr *PullRequest, e *PrEvent) (*OutboxMessage, error) {
+	eventType := PrEventName(e.Type)
+	payload, err := json.Marshal(outboxPayload{
+		Type:       eventType,
+		OccurredAt: e.CreatedAt,
+		Data: prEventPayload{
+			PullRequest: prPayload{
+				ID:                pr.ID,
+				Name:              pr.Name,
+				AuthorID:          pr.AuthorID,
+				Status:            string(pr.Status),
+				AssignedReviewers: pr.AssignedReviewers,
+			},
+			ReviewerID:    e.ReviewerID,
+			OldReviewerID: e.OldReviewerID,
+			FromStatus:    string(e.FromStatus),
+			ToStatus:      string(e.ToStatus),
+			Details:       e.Details,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxMessage{
+		AggregateType: "pull_request",
+		AggregateID:   pr.ID,
+		EventType:     eventType,
+		Payload:       payload,
+		CreatedAt:     e.CreatedAt,
+	}, nil
+}
+
+func NewUserOutboxMessage(u *User, e *UserEvent) (*OutboxMessage, error) {
+	eventType := UserEventName(e.Type)
+	payload, err := json.Marshal(outboxPayload{
+		Type:       eventType,
+		OccurredAt: e.CreatedAt,
+		Data: userEventPayload{
+			UserID:   u.ID,
+			Username: u.Username,
+			TeamName: u.TeamName,
+			IsActive: u.IsActive,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxMessage{
+		AggregateType: "user",
+		AggregateID:   u.ID,
+		EventType:     eventType,
+		Payload:       payload,
+		CreatedAt:     e.CreatedAt,
+	}, nil
+}
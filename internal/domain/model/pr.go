@@ -1,48 +1,254 @@
 package model
 
-import "time"
+import (
+	"strconv"
+	"test/internal/domain/domain_errors"
+	"time"
+)
 
 type Status string
 
 const (
+	StatusDraft  Status = "DRAFT"
 	StatusOpen   Status = "OPEN"
 	StatusMerged Status = "MERGED"
+	StatusClosed Status = "CLOSED"
 )
 
+type ReviewDecision string
+
+const (
+	DecisionApproved         ReviewDecision = "APPROVED"
+	DecisionChangesRequested ReviewDecision = "CHANGES_REQUESTED"
+)
+
+func (d ReviewDecision) IsValid() bool {
+	return d == DecisionApproved || d == DecisionChangesRequested
+}
+
+type Review struct {
+	Decision  ReviewDecision
+	DecidedAt time.Time
+}
+
 type PullRequest struct {
 	ID                string
 	Name              string
 	AuthorID          string
 	Status            Status
 	AssignedReviewers []string
-	CreatedAt         time.Time
-	MergedAt          *time.Time
+	// FallbackReviewers maps reviewers borrowed from a fallback team to that team.
+	FallbackReviewers map[string]string
+	// Reviews holds the latest decision of each assigned reviewer who has submitted one.
+	Reviews   map[string]*Review
+	CreatedAt time.Time
+	MergedAt  *time.Time
+	// MergeOverrideReason is set when the PR was merged without enough approvals.
+	MergeOverrideReason *string
+	// Version is incremented every time a change of the PR is saved.
+	Version int64
+
+	// events are recorded by state changes and persisted together with the PR.
+	events []*PrEvent
 }
 
 func NewPr(id, name, author string) *PullRequest {
-	return &PullRequest{
+	return newPr(id, name, author, StatusOpen)
+}
+
+// NewDraftPr creates a pull request that gets no reviewers until it is marked ready.
+func NewDraftPr(id, name, author string) *PullRequest {
+	return newPr(id, name, author, StatusDraft)
+}
+
+func newPr(id, name, author string, status Status) *PullRequest {
+	pr := &PullRequest{
 		ID:                id,
 		Name:              name,
 		AuthorID:          author,
-		Status:            StatusOpen,
+		Status:            status,
 		CreatedAt:         time.Now(),
 		AssignedReviewers: []string{},
+		FallbackReviewers: map[string]string{},
+		Reviews:           map[string]*Review{},
+		Version:           1,
+	}
+	pr.record(&PrEvent{Type: PrEventCreated, ToStatus: status})
+	return pr
+}
+
+// CheckVersion returns ErrVersionMismatch unless expected is 0 or the current version.
+func (pr *PullRequest) CheckVersion(expected int64) error {
+	if expected != 0 && expected != pr.Version {
+		return domain_errors.ErrVersionMismatch.With("current_version", strconv.FormatInt(pr.Version, 10))
+	}
+	return nil
+}
+
+// PendingEvents returns the events recorded since the PR was loaded or last saved.
+func (pr *PullRequest) PendingEvents() []*PrEvent {
+	return pr.events
+}
+
+func (pr *PullRequest) ClearPendingEvents() {
+	pr.events = nil
+}
+
+func (pr *PullRequest) record(e *PrEvent) {
+	e.PullRequestID = pr.ID
+	e.CreatedAt = time.Now()
+	pr.events = append(pr.events, e)
+}
+
+func (pr *PullRequest) changeStatus(to Status) {
+	pr.record(&PrEvent{Type: PrEventStatusChanged, FromStatus: pr.Status, ToStatus: to})
+	pr.Status = to
+}
+
+// Merge moves an OPEN pull request to MERGED once it has at least minApprovals
+// approvals. A non-empty overrideReason bypasses the approval check and is kept
+// on the PR. Merging a merged PR is a no-op.
+func (pr *PullRequest) Merge(minApprovals int, overrideReason string) error {
+	switch pr.Status {
+	case StatusMerged:
+		return nil
+	case StatusOpen:
+		if overrideReason != "" {
+			pr.MergeOverrideReason = &overrideReason
+		} else if pr.Approvals() < minApprovals {
+			return domain_errors.ErrNotEnoughApprovals.
+				With("approvals", strconv.Itoa(pr.Approvals())).
+				With("min_approvals", strconv.Itoa(minApprovals))
+		}
+		pr.record(&PrEvent{Type: PrEventMerged, FromStatus: pr.Status, ToStatus: StatusMerged, Details: overrideReason})
+		pr.Status = StatusMerged
+		t := time.Now()
+		pr.MergedAt = &t
+		return nil
+	default:
+		return domain_errors.ErrInvalidStatusTransition.With("status", string(pr.Status))
+	}
+}
+
+// Close abandons an OPEN or DRAFT pull request. Closing a closed PR is a no-op.
+func (pr *PullRequest) Close() error {
+	switch pr.Status {
+	case StatusClosed:
+		return nil
+	case StatusOpen, StatusDraft:
+		pr.changeStatus(StatusClosed)
+		return nil
+	case StatusMerged:
+		return domain_errors.ErrPRMerged
+	default:
+		return domain_errors.ErrInvalidStatusTransition.With("status", string(pr.Status))
+	}
+}
+
+// Reopen moves a CLOSED pull request back to OPEN. Reopening an open PR is a no-op.
+func (pr *PullRequest) Reopen() error {
+	switch pr.Status {
+	case StatusOpen:
+		return nil
+	case StatusClosed:
+		pr.changeStatus(StatusOpen)
+		return nil
+	case StatusMerged:
+		return domain_errors.ErrPRMerged
+	default:
+		return domain_errors.ErrInvalidStatusTransition.With("status", string(pr.Status))
+	}
+}
+
+// MarkReady moves a DRAFT pull request to OPEN. Marking an open PR ready is a no-op.
+func (pr *PullRequest) MarkReady() error {
+	switch pr.Status {
+	case StatusOpen:
+		return nil
+	case StatusDraft:
+		pr.changeStatus(StatusOpen)
+		return nil
+	case StatusMerged:
+		return domain_errors.ErrPRMerged
+	default:
+		return domain_errors.ErrInvalidStatusTransition.With("status", string(pr.Status))
+	}
+}
+
+// MarkDraft moves an OPEN pull request back to DRAFT. Assigned reviewers are
+// kept. Marking a draft PR as draft is a no-op.
+func (pr *PullRequest) MarkDraft() error {
+	switch pr.Status {
+	case StatusDraft:
+		return nil
+	case StatusOpen:
+		pr.changeStatus(StatusDraft)
+		return nil
+	case StatusMerged:
+		return domain_errors.ErrPRMerged
+	default:
+		return domain_errors.ErrInvalidStatusTransition.With("status", string(pr.Status))
+	}
+}
+
+func (pr *PullRequest) AssignReviewer(id, fallbackTeam string) {
+	pr.AssignedReviewers = append(pr.AssignedReviewers, id)
+	if fallbackTeam != "" {
+		pr.FallbackReviewers[id] = fallbackTeam
 	}
+	pr.record(&PrEvent{Type: PrEventReviewerAssigned, ReviewerID: id, Details: fallbackTeam})
 }
 
-func (pr *PullRequest) Merge() {
+// SubmitReview records the decision of an assigned reviewer, replacing their previous one.
+func (pr *PullRequest) SubmitReview(reviewerID string, decision ReviewDecision) error {
+	if !decision.IsValid() {
+		return domain_errors.ErrInvalidReviewDecision.With("decision", string(decision))
+	}
 	if pr.Status == StatusMerged {
-		return
+		return domain_errors.ErrPRMerged
+	}
+	if pr.Status != StatusOpen {
+		return domain_errors.ErrPRNotOpen.With("status", string(pr.Status))
+	}
+	if !pr.HasReviewer(reviewerID) {
+		return domain_errors.ErrReviewerNotAssigned.With("reviewer_id", reviewerID)
+	}
+
+	pr.Reviews[reviewerID] = &Review{Decision: decision, DecidedAt: time.Now()}
+	pr.record(&PrEvent{Type: PrEventReviewSubmitted, ReviewerID: reviewerID, Details: string(decision)})
+	return nil
+}
+
+// Approvals counts assigned reviewers whose latest decision is APPROVED.
+func (pr *PullRequest) Approvals() int {
+	n := 0
+	for _, id := range pr.AssignedReviewers {
+		if r, ok := pr.Reviews[id]; ok && r.Decision == DecisionApproved {
+			n++
+		}
+	}
+	return n
+}
+
+func (pr *PullRequest) HasReviewer(id string) bool {
+	for _, r := range pr.AssignedReviewers {
+		if r == id {
+			return true
+		}
 	}
-	pr.Status = StatusMerged
-	t := time.Now()
-	pr.MergedAt = &t
+	return false
 }
 
-func (pr *PullRequest) ReplaceReviewer(old, new string) {
+func (pr *PullRequest) ReplaceReviewer(old, new, fallbackTeam string) {
 	for i, r := range pr.AssignedReviewers {
 		if r == old {
 			pr.AssignedReviewers[i] = new
+			delete(pr.FallbackReviewers, old)
+			delete(pr.Reviews, old)
+			if fallbackTeam != "" {
+				pr.FallbackReviewers[new] = fallbackTeam
+			}
+			pr.record(&PrEvent{Type: PrEventReviewerReassigned, ReviewerID: new, OldReviewerID: old, Details: fallbackTeam})
 			return
 		}
 	}
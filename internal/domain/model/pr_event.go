@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+type PrEventType string
+
+const (
+	PrEventCreated            PrEventType = "CREATED"
+	PrEventReviewerAssigned   PrEventType = "REVIEWER_ASSIGNED"
+	PrEventReviewerReassigned PrEventType = "REVIEWER_REASSIGNED"
+	PrEventReviewSubmitted    PrEventType = "REVIEW_SUBMITTED"
+	PrEventMerged             PrEventType = "MERGED"
+	PrEventStatusChanged      PrEventType = "STATUS_CHANGED"
+)
+
+// PrEvent is an append-only record of a pull request state change.
+// Fields that do not apply to the event type are left empty.
+type PrEvent struct {
+	ID            int64
+	PullRequestID string
+	Type          PrEventType
+	ReviewerID    string
+	OldReviewerID string
+	FromStatus    Status
+	ToStatus      Status
+	Details       string
+	CreatedAt     time.Time
+}
@@ -0,0 +1,18 @@
+package model
+
+type SelectionStrategy string
+
+const (
+	StrategyRandom      SelectionStrategy = "random"
+	StrategyRoundRobin  SelectionStrategy = "round_robin"
+	StrategyLeastLoaded SelectionStrategy = "least_loaded"
+	StrategyWeighted    SelectionStrategy = "weighted"
+)
+
+func (s SelectionStrategy) IsValid() bool {
+	switch s {
+	case StrategyRandom, StrategyRoundRobin, StrategyLeastLoaded, StrategyWeighted:
+		return true
+	}
+	return false
+}
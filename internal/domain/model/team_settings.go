@@ -0,0 +1,64 @@
+package model
+
+const (
+	DefaultReviewersPerPR = 2
+	MaxReviewersPerPR     = 10
+)
+
+// TeamSettings holds the reviewer assignment policy of a team.
+// An empty Strategy means the deployment-wide default is used.
+// FallbackTeams are consulted in order only when AllowCrossTeam is set.
+type TeamSettings struct {
+	TeamName       string
+	ReviewersPerPR int
+	Strategy       SelectionStrategy
+	AllowCrossTeam bool
+	FallbackTeams  []string
+	// MinApprovals is the number of approvals required to merge a PR of the team.
+	MinApprovals int
+}
+
+func NewDefaultTeamSettings(team string) *TeamSettings {
+	return &TeamSettings{
+		TeamName:       team,
+		ReviewersPerPR: DefaultReviewersPerPR,
+		FallbackTeams:  []string{},
+	}
+}
+
+func (s *TeamSettings) IsValid() bool {
+	if s.ReviewersPerPR < 0 || s.ReviewersPerPR > MaxReviewersPerPR {
+		return false
+	}
+	// A PR never has more approvals than reviewers, so a higher minimum could
+	// only be merged with an override.
+	if s.MinApprovals < 0 || s.MinApprovals > s.ReviewersPerPR {
+		return false
+	}
+	if s.Strategy != "" && !s.Strategy.IsValid() {
+		return false
+	}
+
+	seen := make(map[string]bool, len(s.FallbackTeams))
+	for _, t := range s.FallbackTeams {
+		if t == "" || t == s.TeamName || seen[t] {
+			return false
+		}
+		seen[t] = true
+	}
+	return true
+}
+
+// CandidatePools returns the teams to draw reviewers from, home team first.
+func (s *TeamSettings) CandidatePools(home string) []string {
+	pools := []string{home}
+	if !s.AllowCrossTeam {
+		return pools
+	}
+	for _, t := range s.FallbackTeams {
+		if t != home {
+			pools = append(pools, t)
+		}
+	}
+	return pools
+}
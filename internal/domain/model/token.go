@@ -0,0 +1,50 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+type Role string
+
+const (
+	// RoleAdmin may call every operation.
+	RoleAdmin Role = "ADMIN"
+	// RoleUser acts on behalf of the user the token is issued to.
+	RoleUser Role = "USER"
+)
+
+func (r Role) IsValid() bool {
+	return r == RoleAdmin || r == RoleUser
+}
+
+// APIToken is a bearer token for the API. Only the hash of the token is
+// stored; the token itself is shown once, when it is created.
+type APIToken struct {
+	ID   string
+	Name string
+	Role Role
+	// UserID is the user the token acts for, required for RoleUser.
+	UserID    string
+	Hash      string
+	CreatedAt time.Time
+}
+
+func NewAPIToken(id, name string, role Role, userID, hash string) *APIToken {
+	return &APIToken{
+		ID:        id,
+		Name:      name,
+		Role:      role,
+		UserID:    userID,
+		Hash:      hash,
+		CreatedAt: time.Now(),
+	}
+}
+
+// HashToken returns the stored form of a token. Tokens are random, so a plain
+// SHA-256 is enough to make a leaked table useless.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,10 +1,34 @@
 package model
 
+import (
+	"strconv"
+	"test/internal/domain/domain_errors"
+	"time"
+)
+
+type UserEventType string
+
+const (
+	UserEventActivated   UserEventType = "ACTIVATED"
+	UserEventDeactivated UserEventType = "DEACTIVATED"
+)
+
+type UserEvent struct {
+	UserID    string
+	Type      UserEventType
+	CreatedAt time.Time
+}
+
 type User struct {
 	ID       string
 	Username string
 	TeamName string
 	IsActive bool
+	// Version is incremented every time a change of the user is saved.
+	Version int64
+
+	// events are recorded by state changes and persisted together with the user.
+	events []*UserEvent
 }
 
 func NewUser(id, username, team string, active bool) *User {
@@ -13,13 +37,43 @@ func NewUser(id, username, team string, active bool) *User {
 		Username: username,
 		TeamName: team,
 		IsActive: active,
+		Version:  1,
+	}
+}
+
+// CheckVersion returns ErrVersionMismatch unless expected is 0 or the current version.
+func (u *User) CheckVersion(expected int64) error {
+	if expected != 0 && expected != u.Version {
+		return domain_errors.ErrVersionMismatch.With("current_version", strconv.FormatInt(u.Version, 10))
 	}
+	return nil
 }
 
 func (u *User) Deactivate() {
+	if !u.IsActive {
+		return
+	}
 	u.IsActive = false
+	u.record(UserEventDeactivated)
 }
 
 func (u *User) Activate() {
+	if u.IsActive {
+		return
+	}
 	u.IsActive = true
+	u.record(UserEventActivated)
+}
+
+// PendingEvents returns the events recorded since the user was loaded or last saved.
+func (u *User) PendingEvents() []*UserEvent {
+	return u.events
+}
+
+func (u *User) ClearPendingEvents() {
+	u.events = nil
+}
+
+func (u *User) record(t UserEventType) {
+	u.events = append(u.events, &UserEvent{UserID: u.ID, Type: t, CreatedAt: time.Now()})
 }
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// WebhookSubscription is an outbound HTTP endpoint notified about domain events.
+// An empty EventTypes list subscribes to every event.
+type WebhookSubscription struct {
+	ID         string
+	URL        string
+	Secret     string
+	EventTypes []string
+	CreatedAt  time.Time
+}
+
+func NewWebhookSubscription(id, url, secret string, eventTypes []string) *WebhookSubscription {
+	return &WebhookSubscription{
+		ID:         id,
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		CreatedAt:  time.Now(),
+	}
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	DeliveryDead      DeliveryStatus = "DEAD"
+)
+
+// WebhookDelivery is one attempt series of sending an outbox event to a subscription.
+type WebhookDelivery struct {
+	ID             int64
+	EventID        int64
+	EventType      string
+	SubscriptionID string
+	URL            string
+	Secret         string
+	Payload        []byte
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastError      string
+	DeliveredAt    *time.Time
+}
+
+// PrEventName is the outbox event type of a pull request event, e.g. "pull_request.merged".
+func PrEventName(t PrEventType) string {
+	return "pull_request." + strings.ToLower(string(t))
+}
+
+// UserEventName is the outbox event type of a user event, e.g. "user.deactivated".
+func UserEventName(t UserEventType) string {
+	return "user." + strings.ToLower(string(t))
+}
+
+func IsKnownEventName(name string) bool {
+	for _, t := range []PrEventType{PrEventCreated, PrEventReviewerAssigned, PrEventReviewerReassigned, PrEventReviewSubmitted, PrEventMerged, PrEventStatusChanged} {
+		if PrEventName(t) == name {
+			return true
+		}
+	}
+	for _, t := range []UserEventType{UserEventActivated, UserEventDeactivated} {
+		if UserEventName(t) == name {
+			return true
+		}
+	}
+	return false
+}
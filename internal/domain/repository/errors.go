@@ -0,0 +1,7 @@
+package repository
+
+import "errors"
+
+// ErrForeignKeyViolation is returned by writes that reference a missing team or
+// user. Every implementation reports it, whatever its storage enforces.
+var ErrForeignKeyViolation = errors.New("foreign key violation")
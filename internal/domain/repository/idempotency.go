@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"test/internal/domain/model"
+)
+
+type IdempotencyRepository interface {
+	// Reserve stores rec as in progress. If an unexpired record with the same key
+	// already exists, nothing is stored and the existing record is returned.
+	Reserve(ctx context.Context, rec *model.IdempotencyRecord) (*model.IdempotencyRecord, error)
+	// Complete stores the response of a reserved record.
+	Complete(ctx context.Context, rec *model.IdempotencyRecord) error
+	// Release drops a reservation so that the request can be retried.
+	Release(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
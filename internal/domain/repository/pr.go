@@ -7,7 +7,19 @@ import (
 
 type PrRepository interface {
 	GetByID(ctx context.Context, id string) (*model.PullRequest, error)
+	// GetByIDForUpdate is GetByID that also locks the pull request until the end
+	// of the current transaction.
+	GetByIDForUpdate(ctx context.Context, id string) (*model.PullRequest, error)
+	// Create stores a new pull request and returns ErrPullRequestExists if the id is taken.
+	Create(ctx context.Context, pr *model.PullRequest) error
+	// Save updates an existing pull request and returns ErrPullRequestNotFound if there is none.
 	Save(ctx context.Context, pr *model.PullRequest) error
 	GetByReviewer(ctx context.Context, reviewerID string) ([]*model.PullRequest, error)
 	CheckUserOpenPRs(ctx context.Context, userIDs []string) (bool, error)
+	CountOpenReviewsByTeam(ctx context.Context, team string) (map[string]int, error)
+	// CountOpenReviews returns, for every user, the number of open pull requests
+	// they are assigned to review.
+	CountOpenReviews(ctx context.Context) (map[string]int, error)
+	// GetHistory returns the events of a pull request, oldest first.
+	GetHistory(ctx context.Context, prID string) ([]*model.PrEvent, error)
 }
@@ -8,4 +8,6 @@ import (
 type TeamRepository interface {
 	Create(ctx context.Context, team *model.Team) error
 	GetByName(ctx context.Context, name string) (*model.Team, error)
+	GetSettings(ctx context.Context, team string) (*model.TeamSettings, error)
+	SaveSettings(ctx context.Context, settings *model.TeamSettings) error
 }
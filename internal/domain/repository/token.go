@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"test/internal/domain/model"
+)
+
+type TokenRepository interface {
+	Create(ctx context.Context, t *model.APIToken) error
+	GetByID(ctx context.Context, id string) (*model.APIToken, error)
+	GetByHash(ctx context.Context, hash string) (*model.APIToken, error)
+	List(ctx context.Context) ([]*model.APIToken, error)
+	Delete(ctx context.Context, id string) error
+}
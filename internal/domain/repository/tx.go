@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// TxManager runs a unit of work in a single transaction. Repository calls made
+// with the context passed to fn take part in it; the transaction commits when fn
+// returns nil and rolls back otherwise. Nested calls join the outer transaction.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
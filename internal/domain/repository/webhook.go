@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"test/internal/domain/model"
+	"time"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, s *model.WebhookSubscription) error
+	GetByID(ctx context.Context, id string) (*model.WebhookSubscription, error)
+	List(ctx context.Context) ([]*model.WebhookSubscription, error)
+	Delete(ctx context.Context, id string) error
+	GetDeliveries(ctx context.Context, subscriptionID string, status model.DeliveryStatus) ([]*model.WebhookDelivery, error)
+}
+
+// OutboxRepository drives delivery of events written to the outbox.
+type OutboxRepository interface {
+	// FanOut turns up to limit undispatched outbox events into pending deliveries
+	// for every matching subscription and returns how many events were processed.
+	FanOut(ctx context.Context, limit int) (int, error)
+	// ClaimDeliveries returns up to limit due deliveries and hides them from other
+	// dispatchers for the lease duration.
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id int64) error
+	// MarkFailed records a failed attempt. A nil nextAttemptAt moves the delivery to DEAD.
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt *time.Time) error
+}
@@ -0,0 +1,21 @@
+package service
+
+import "time"
+
+// Metrics records pull request activity. Team labels are the team of the PR
+// author. Changes are reported once their unit of work has committed.
+type Metrics interface {
+	PrCreated(team string)
+	PrMerged(team string, timeToMerge time.Duration)
+	ReviewerReassigned(team string)
+	// NoReplacementCandidate is reported when a reassignment finds nobody to pick.
+	NoReplacementCandidate(team string)
+}
+
+// NopMetrics discards all measurements.
+type NopMetrics struct{}
+
+func (NopMetrics) PrCreated(string)               {}
+func (NopMetrics) PrMerged(string, time.Duration) {}
+func (NopMetrics) ReviewerReassigned(string)      {}
+func (NopMetrics) NoReplacementCandidate(string)  {}
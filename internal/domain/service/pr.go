@@ -2,150 +2,460 @@ package service
 
 import (
 	"context"
-	"math/rand"
+	"errors"
+	"log/slog"
 	"test/internal/domain/domain_errors"
 	"test/internal/domain/model"
 	"test/internal/domain/repository"
-	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type PrService struct {
 	prRepo   repository.PrRepository
 	userRepo repository.UserRepository
 	teamRepo repository.TeamRepository
+	txm      repository.TxManager
+	registry SelectorRegistry
+	selector ReviewerSelector
+	metrics  Metrics
 }
 
-func NewPrService(pr repository.PrRepository, u repository.UserRepository, t repository.TeamRepository) *PrService {
+// NewPrService creates the service. selector is used for teams whose settings
+// do not name a strategy from registry.
+func NewPrService(pr repository.PrRepository, u repository.UserRepository, t repository.TeamRepository, txm repository.TxManager, registry SelectorRegistry, selector ReviewerSelector, metrics Metrics) *PrService {
 	return &PrService{
 		prRepo:   pr,
 		userRepo: u,
 		teamRepo: t,
+		txm:      txm,
+		registry: registry,
+		selector: selector,
+		metrics:  metrics,
 	}
 }
 
-func (s *PrService) CreatePR(ctx context.Context, id, name, authorId string) (*model.PullRequest, error) {
-	pr, err := s.prRepo.GetByID(ctx, id)
+// CreatePR creates a pull request and assigns reviewers to it. Draft pull requests
+// get no reviewers until MarkReady is called.
+func (s *PrService) CreatePR(ctx context.Context, id, name, authorId string, draft bool) (_ *model.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PrService.CreatePR", attribute.String("pull_request.id", id), attribute.String("user.id", authorId), attribute.Bool("pull_request.draft", draft))
+	defer func() { endSpan(span, err) }()
+
+	var pr *model.PullRequest
+	var team string
+	var logs pendingLogs
+	err = s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.prRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return domain_errors.ErrPullRequestExists.With("pull_request_id", id)
+		}
+
+		author, err := s.userRepo.GetByID(ctx, authorId)
+		if err != nil {
+			return err
+		}
+		if author == nil {
+			return domain_errors.ErrUserNotFound.With("user_id", authorId)
+		}
+		team = author.TeamName
+
+		if draft {
+			pr = model.NewDraftPr(id, name, authorId)
+		} else {
+			pr = model.NewPr(id, name, authorId)
+			if err := s.assignReviewers(ctx, pr, author, &logs); err != nil {
+				return err
+			}
+		}
+
+		return s.prRepo.Create(ctx, pr)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if pr != nil {
-		return nil, domain_errors.ErrPullRequestExists
-	}
+	logs.flush(ctx)
+
+	s.metrics.PrCreated(team)
+	return pr, nil
+}
+
+// Merge merges a pull request that has the number of approvals required by the
+// author's team. A non-empty overrideReason merges it regardless.
+func (s *PrService) Merge(ctx context.Context, id, overrideReason string, version int64) (_ *model.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PrService.Merge", attribute.String("pull_request.id", id), attribute.Bool("pull_request.override", overrideReason != ""))
+	defer func() { endSpan(span, err) }()
 
-	author, err := s.userRepo.GetByID(ctx, authorId)
+	merged := false
+	var team string
+	pr, err := s.update(ctx, id, version, func(ctx context.Context, pr *model.PullRequest) error {
+		if pr.Status == model.StatusMerged {
+			return nil
+		}
+
+		minApprovals := 0
+		author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+		if author != nil {
+			team = author.TeamName
+			settings, err := s.teamSettings(ctx, author.TeamName)
+			if err != nil {
+				return err
+			}
+			minApprovals = settings.MinApprovals
+		}
+
+		if err := pr.Merge(minApprovals, overrideReason); err != nil {
+			return err
+		}
+		merged = true
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if author == nil {
-		return nil, domain_errors.ErrUserNotFound
+
+	if merged && pr.MergedAt != nil {
+		s.metrics.PrMerged(team, pr.MergedAt.Sub(pr.CreatedAt))
 	}
+	return pr, nil
+}
+
+// SubmitReview records the decision of an assigned reviewer on an open pull request.
+func (s *PrService) SubmitReview(ctx context.Context, id, reviewerID string, decision model.ReviewDecision, version int64) (_ *model.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PrService.SubmitReview", attribute.String("pull_request.id", id), attribute.String("user.id", reviewerID), attribute.String("review.decision", string(decision)))
+	defer func() { endSpan(span, err) }()
+
+	return s.update(ctx, id, version, func(_ context.Context, pr *model.PullRequest) error {
+		return pr.SubmitReview(reviewerID, decision)
+	})
+}
 
-	users, err := s.userRepo.GetActiveByTeam(ctx, author.TeamName)
+func (s *PrService) Close(ctx context.Context, id string, version int64) (_ *model.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PrService.Close", attribute.String("pull_request.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.update(ctx, id, version, func(_ context.Context, pr *model.PullRequest) error {
+		return pr.Close()
+	})
+}
+
+// Reopen reopens a closed pull request. A PR that was closed as a draft has
+// no reviewers yet, so they are assigned on reopen.
+func (s *PrService) Reopen(ctx context.Context, id string, version int64) (_ *model.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PrService.Reopen", attribute.String("pull_request.id", id))
+	defer func() { endSpan(span, err) }()
+
+	var logs pendingLogs
+	pr, err := s.update(ctx, id, version, func(ctx context.Context, pr *model.PullRequest) error {
+		wasOpen := pr.Status == model.StatusOpen
+		if err := pr.Reopen(); err != nil {
+			return err
+		}
+		if wasOpen || len(pr.AssignedReviewers) > 0 {
+			return nil
+		}
+		return s.assignAuthorReviewers(ctx, pr, &logs)
+	})
 	if err != nil {
 		return nil, err
 	}
+	logs.flush(ctx)
+	return pr, nil
+}
 
-	pr = model.NewPr(id, name, authorId)
+// MarkReady takes a pull request out of draft and fills its reviewer slots.
+func (s *PrService) MarkReady(ctx context.Context, id string, version int64) (_ *model.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PrService.MarkReady", attribute.String("pull_request.id", id))
+	defer func() { endSpan(span, err) }()
 
-	var candidates []string
-	for _, m := range users {
-		if m.ID == authorId {
-			continue
+	var logs pendingLogs
+	pr, err := s.update(ctx, id, version, func(ctx context.Context, pr *model.PullRequest) error {
+		wasOpen := pr.Status == model.StatusOpen
+		if err := pr.MarkReady(); err != nil {
+			return err
+		}
+		if wasOpen {
+			return nil
 		}
-		candidates = append(candidates, m.ID)
+		return s.assignAuthorReviewers(ctx, pr, &logs)
+	})
+	if err != nil {
+		return nil, err
 	}
+	logs.flush(ctx)
+	return pr, nil
+}
+
+// MarkDraft moves an open pull request back to draft, as when it is converted
+// to a draft on the hosting side.
+func (s *PrService) MarkDraft(ctx context.Context, id string, version int64) (_ *model.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PrService.MarkDraft", attribute.String("pull_request.id", id))
+	defer func() { endSpan(span, err) }()
 
-	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
-	rnd.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
+	return s.update(ctx, id, version, func(_ context.Context, pr *model.PullRequest) error {
+		return pr.MarkDraft()
 	})
+}
+
+func (s *PrService) ReassignReviewer(ctx context.Context, id, oldReviewerId string, version int64) (_ *model.PullRequest, _ string, err error) {
+	ctx, span := startSpan(ctx, "PrService.ReassignReviewer", attribute.String("pull_request.id", id), attribute.String("user.id", oldReviewerId))
+	defer func() { endSpan(span, err) }()
+
+	var newReviewerId, homeTeam string
+	var pools []string
+	var logs pendingLogs
+	pr, err := s.update(ctx, id, version, func(ctx context.Context, pr *model.PullRequest) error {
+		if pr.Status == model.StatusMerged {
+			return domain_errors.ErrPRMerged
+		}
+		if pr.Status != model.StatusOpen {
+			return domain_errors.ErrPRNotOpen.With("status", string(pr.Status))
+		}
+
+		if !contains(pr.AssignedReviewers, oldReviewerId) {
+			return domain_errors.ErrReviewerNotAssigned.With("reviewer_id", oldReviewerId)
+		}
+
+		oldReviewer, err := s.userRepo.GetByID(ctx, oldReviewerId)
+		if err != nil {
+			return err
+		}
+		if oldReviewer == nil {
+			return domain_errors.ErrUserNotFound.With("user_id", oldReviewerId)
+		}
+
+		homeTeam = oldReviewer.TeamName
+		author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+		if author != nil {
+			homeTeam = author.TeamName
+		}
+
+		settings, err := s.teamSettings(ctx, homeTeam)
+		if err != nil {
+			return err
+		}
+
+		exclude := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+		// Like CreatePR, start from the author's team: a reviewer borrowed from a
+		// fallback team is replaced from the home team first.
+		pools = settings.CandidatePools(homeTeam)
+		picked, err := s.pickReviewers(ctx, settings, pools, exclude, 1, &logs)
+		if err != nil {
+			return err
+		}
+		if len(picked) == 0 {
+			return domain_errors.ErrNoReplacementCandidate.With("reviewer_id", oldReviewerId)
+		}
+		newReviewerId = picked[0].id
+		logs.add(slog.LevelInfo, "reviewer reassigned",
+			"pull_request_id", pr.ID, "old_reviewer_id", oldReviewerId, "new_reviewer_id", newReviewerId, "fallback_team", picked[0].fallbackTeam)
 
-	if len(candidates) > 2 {
-		candidates = candidates[:2]
+		pr.ReplaceReviewer(oldReviewerId, newReviewerId, picked[0].fallbackTeam)
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, domain_errors.ErrNoReplacementCandidate) {
+			slog.WarnContext(ctx, "no replacement candidate",
+				"pull_request_id", id, "old_reviewer_id", oldReviewerId, "team", homeTeam, "pools", pools)
+			s.metrics.NoReplacementCandidate(homeTeam)
+		}
+		return nil, "", err
 	}
+	logs.flush(ctx)
 
-	pr.AssignedReviewers = candidates
+	s.metrics.ReviewerReassigned(homeTeam)
+	return pr, newReviewerId, nil
+}
+
+func (s *PrService) GetByID(ctx context.Context, id string) (_ *model.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PrService.GetByID", attribute.String("pull_request.id", id))
+	defer func() { endSpan(span, err) }()
 
-	if err := s.prRepo.Save(ctx, pr); err != nil {
+	pr, err := s.prRepo.GetByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
-
+	if pr == nil {
+		return nil, domain_errors.ErrPullRequestNotFound.With("pull_request_id", id)
+	}
 	return pr, nil
 }
 
-func (s *PrService) Merge(ctx context.Context, id string) (*model.PullRequest, error) {
+func (s *PrService) GetByReviewer(ctx context.Context, id string) (_ []*model.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PrService.GetByReviewer", attribute.String("user.id", id))
+	defer func() { endSpan(span, err) }()
+
+	return s.prRepo.GetByReviewer(ctx, id)
+}
+
+func (s *PrService) GetHistory(ctx context.Context, id string) (_ []*model.PrEvent, err error) {
+	ctx, span := startSpan(ctx, "PrService.GetHistory", attribute.String("pull_request.id", id))
+	defer func() { endSpan(span, err) }()
+
 	pr, err := s.prRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	if pr == nil {
-		return nil, domain_errors.ErrPullRequestNotFound
+		return nil, domain_errors.ErrPullRequestNotFound.With("pull_request_id", id)
 	}
+	return s.prRepo.GetHistory(ctx, id)
+}
 
-	pr.Merge()
+// update locks the pull request, applies change to it and saves the result in
+// one transaction, so concurrent changes of the same pull request are serialized.
+// A non-zero version must match the current version of the pull request. Nothing
+// is saved, and the version stays the same, when change records no events.
+func (s *PrService) update(ctx context.Context, id string, version int64, change func(ctx context.Context, pr *model.PullRequest) error) (*model.PullRequest, error) {
+	var pr *model.PullRequest
+	err := s.txm.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if pr == nil {
+			return domain_errors.ErrPullRequestNotFound.With("pull_request_id", id)
+		}
+		if err := pr.CheckVersion(version); err != nil {
+			return err
+		}
 
-	if err := s.prRepo.Save(ctx, pr); err != nil {
+		if err := change(ctx, pr); err != nil {
+			return err
+		}
+		if len(pr.PendingEvents()) == 0 {
+			return nil
+		}
+		return s.prRepo.Save(ctx, pr)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return pr, nil
 }
 
-func (s *PrService) ReassignReviewer(ctx context.Context, id, oldReviewerId string) (*model.PullRequest, string, error) {
-	pr, err := s.prRepo.GetByID(ctx, id)
+func (s *PrService) assignAuthorReviewers(ctx context.Context, pr *model.PullRequest, logs *pendingLogs) error {
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
 	if err != nil {
-		return nil, "", err
-	}
-	if pr == nil {
-		return nil, "", domain_errors.ErrPullRequestNotFound
+		return err
 	}
-	if pr.Status == model.StatusMerged {
-		return nil, "", domain_errors.ErrPRMerged
+	if author == nil {
+		return domain_errors.ErrUserNotFound.With("user_id", pr.AuthorID)
 	}
+	return s.assignReviewers(ctx, pr, author, logs)
+}
 
-	if !contains(pr.AssignedReviewers, oldReviewerId) {
-		return nil, "", domain_errors.ErrReviewerNotAssigned
+// assignReviewers fills the reviewer slots of pr according to the settings of the author's team.
+func (s *PrService) assignReviewers(ctx context.Context, pr *model.PullRequest, author *model.User, logs *pendingLogs) error {
+	settings, err := s.teamSettings(ctx, author.TeamName)
+	if err != nil {
+		return err
 	}
 
-	oldReviewer, err := s.userRepo.GetByID(ctx, oldReviewerId)
+	exclude := append([]string{author.ID}, pr.AssignedReviewers...)
+	pools := settings.CandidatePools(author.TeamName)
+	wanted := settings.ReviewersPerPR - len(pr.AssignedReviewers)
+	reviewers, err := s.pickReviewers(ctx, settings, pools, exclude, wanted, logs)
 	if err != nil {
-		return nil, "", err
+		return err
 	}
-	if oldReviewer == nil {
-		return nil, "", domain_errors.ErrUserNotFound
+	if len(reviewers) < wanted {
+		logs.add(slog.LevelInfo, "fewer reviewers available than requested",
+			"pull_request_id", pr.ID, "team", author.TeamName, "pools", pools, "wanted", wanted, "assigned", len(reviewers))
 	}
 
-	users, err := s.userRepo.GetActiveByTeam(ctx, oldReviewer.TeamName)
-	if err != nil {
-		return nil, "", err
+	for _, r := range reviewers {
+		pr.AssignReviewer(r.id, r.fallbackTeam)
 	}
+	return nil
+}
 
-	var candidates []string
-	for _, m := range users {
-		if m.ID == oldReviewerId || contains(pr.AssignedReviewers, m.ID) {
-			continue
-		}
-		candidates = append(candidates, m.ID)
-	}
+type pickedReviewer struct {
+	id           string
+	fallbackTeam string
+}
 
-	if len(candidates) == 0 {
-		return nil, "", domain_errors.ErrNoReplacementCandidate
+// pickReviewers fills up to n reviewer slots from pools in order, skipping
+// excluded users. Reviewers from a team other than settings.TeamName are
+// reported with that team as their fallback team.
+func (s *PrService) pickReviewers(ctx context.Context, settings *model.TeamSettings, pools []string, exclude []string, n int, logs *pendingLogs) ([]pickedReviewer, error) {
+	excluded := make(map[string]bool, len(exclude))
+	for _, id := range exclude {
+		excluded[id] = true
 	}
 
-	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
-	newReviewerId := candidates[rnd.Intn(len(candidates))]
+	selector := s.selectorFor(settings)
+	var picked []pickedReviewer
+	for _, team := range pools {
+		if len(picked) >= n {
+			break
+		}
+
+		users, err := s.userRepo.GetActiveByTeam(ctx, team)
+		if err != nil {
+			return nil, err
+		}
 
-	pr.ReplaceReviewer(oldReviewerId, newReviewerId)
+		var candidates []string
+		for _, u := range users {
+			if !excluded[u.ID] {
+				candidates = append(candidates, u.ID)
+			}
+		}
+
+		chosen, err := selector.Select(ctx, team, candidates, n-len(picked))
+		if err != nil {
+			return nil, err
+		}
+		logs.add(slog.LevelInfo, "reviewers selected",
+			"team", settings.TeamName, "pool", team, "strategy", strategyName(settings),
+			"candidates", candidates, "slots", n-len(picked), "chosen", chosen)
+
+		fallbackTeam := ""
+		if team != settings.TeamName {
+			fallbackTeam = team
+		}
+		for _, id := range chosen {
+			excluded[id] = true
+			picked = append(picked, pickedReviewer{id: id, fallbackTeam: fallbackTeam})
+		}
+	}
+	return picked, nil
+}
 
-	err = s.prRepo.Save(ctx, pr)
+func (s *PrService) teamSettings(ctx context.Context, team string) (*model.TeamSettings, error) {
+	settings, err := s.teamRepo.GetSettings(ctx, team)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
+	if settings == nil {
+		return model.NewDefaultTeamSettings(team), nil
+	}
+	return settings, nil
+}
 
-	return pr, newReviewerId, nil
+// strategyName names the strategy applied to the team for logs.
+func strategyName(settings *model.TeamSettings) string {
+	if settings.Strategy == "" {
+		return "default"
+	}
+	return string(settings.Strategy)
 }
 
-func (s *PrService) GetByReviewer(ctx context.Context, id string) ([]*model.PullRequest, error) {
-	return s.prRepo.GetByReviewer(ctx, id)
+func (s *PrService) selectorFor(settings *model.TeamSettings) ReviewerSelector {
+	if sel, ok := s.registry[settings.Strategy]; ok {
+		return sel
+	}
+	return s.selector
 }
 
 func contains(list []string, v string) bool {
@@ -156,3 +466,25 @@ func contains(list []string, v string) bool {
 	}
 	return false
 }
+
+// pendingLogs holds log records made inside a transaction until it commits,
+// so that a rolled back attempt does not log reviewers that were never saved.
+type pendingLogs []pendingLog
+
+type pendingLog struct {
+	level slog.Level
+	msg   string
+	args  []any
+}
+
+func (l *pendingLogs) add(level slog.Level, msg string, args ...any) {
+	*l = append(*l, pendingLog{level: level, msg: msg, args: args})
+}
+
+// flush writes the held records; call it only after the transaction succeeded.
+func (l *pendingLogs) flush(ctx context.Context) {
+	for _, r := range *l {
+		slog.Log(ctx, r.level, r.msg, r.args...)
+	}
+	*l = nil
+}
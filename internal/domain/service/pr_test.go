@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"test/internal/domain/model"
+	"test/internal/domain/service"
+	"test/internal/infrastructure/persistence/memory"
+)
+
+type prFixture struct {
+	users *memory.UserRepository
+	teams *memory.TeamRepository
+	prs   *memory.PrRepository
+	svc   *service.PrService
+}
+
+func newPrFixture(t *testing.T) *prFixture {
+	t.Helper()
+	store := memory.NewStore()
+	users := memory.NewUserRepository(store)
+	f := &prFixture{
+		users: users,
+		teams: memory.NewTeamRepository(store, users),
+		prs:   memory.NewPrRepository(store),
+	}
+	f.svc = service.NewPrService(f.prs, users, f.teams, memory.NewTxManager(store),
+		service.NewSelectorRegistry(f.prs, nil), service.NewRandomSelector(), service.NopMetrics{})
+	return f
+}
+
+func (f *prFixture) team(t *testing.T, name string, ids ...string) {
+	t.Helper()
+	members := make([]*model.User, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, model.NewUser(id, id, name, true))
+	}
+	if err := f.teams.Create(context.Background(), model.NewTeam(name, members)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// backendWithFallback creates the team backend, which borrows reviewers from
+// fallback, and an open PR of a1 reviewed by b2 and by f1 of fallback.
+func (f *prFixture) backendWithFallback(t *testing.T, backend ...string) {
+	t.Helper()
+	ctx := context.Background()
+	f.team(t, "backend", backend...)
+	f.team(t, "fallback", "f1", "f2")
+	err := f.teams.SaveSettings(ctx, &model.TeamSettings{
+		TeamName:       "backend",
+		ReviewersPerPR: 2,
+		AllowCrossTeam: true,
+		FallbackTeams:  []string{"fallback"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pr := model.NewPr("pr-1", "Add fallback teams", "a1")
+	pr.AssignReviewer("b2", "")
+	pr.AssignReviewer("f1", "fallback")
+	if err := f.prs.Create(ctx, pr); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReassignFallbackReviewerTriesHomeTeamFirst(t *testing.T) {
+	f := newPrFixture(t)
+	f.backendWithFallback(t, "a1", "b2", "b3")
+
+	pr, replacedBy, err := f.svc.ReassignReviewer(context.Background(), "pr-1", "f1", 0)
+	if err != nil {
+		t.Fatalf("ReassignReviewer: %v", err)
+	}
+	if replacedBy != "b3" {
+		t.Errorf("replaced by %s, want b3 of the author's team", replacedBy)
+	}
+	if team, ok := pr.FallbackReviewers[replacedBy]; ok {
+		t.Errorf("%s is reported as a reviewer from fallback team %s", replacedBy, team)
+	}
+}
+
+func TestReassignFallbackReviewerKeepsFallbackTeam(t *testing.T) {
+	f := newPrFixture(t)
+	f.backendWithFallback(t, "a1", "b2")
+
+	pr, replacedBy, err := f.svc.ReassignReviewer(context.Background(), "pr-1", "f1", 0)
+	if err != nil {
+		t.Fatalf("ReassignReviewer: %v", err)
+	}
+	if replacedBy != "f2" {
+		t.Fatalf("replaced by %s, want f2", replacedBy)
+	}
+	if team := pr.FallbackReviewers["f2"]; team != "fallback" {
+		t.Errorf("fallback team of f2 = %q, want fallback", team)
+	}
+	if _, ok := pr.FallbackReviewers["f1"]; ok {
+		t.Error("f1 is still reported as a fallback reviewer")
+	}
+}
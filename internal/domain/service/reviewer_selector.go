@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"test/internal/domain/model"
+	"test/internal/domain/repository"
+)
+
+// ReviewerSelector picks up to n reviewers out of candidates of the given team.
+// Implementations must not return ids that are not in candidates.
+type ReviewerSelector interface {
+	Select(ctx context.Context, team string, candidates []string, n int) ([]string, error)
+}
+
+// SelectorRegistry maps a strategy name to its selector.
+type SelectorRegistry map[model.SelectionStrategy]ReviewerSelector
+
+// NewSelectorRegistry builds a registry with all built-in strategies.
+func NewSelectorRegistry(prRepo repository.PrRepository, weights map[string]int) SelectorRegistry {
+	return SelectorRegistry{
+		model.StrategyRandom:      NewRandomSelector(),
+		model.StrategyRoundRobin:  NewRoundRobinSelector(),
+		model.StrategyLeastLoaded: NewLeastLoadedSelector(prRepo),
+		model.StrategyWeighted:    NewWeightedSelector(weights),
+	}
+}
+
+type RandomSelector struct{}
+
+func NewRandomSelector() *RandomSelector {
+	return &RandomSelector{}
+}
+
+func (s *RandomSelector) Select(_ context.Context, _ string, candidates []string, n int) ([]string, error) {
+	picked := shuffled(candidates)
+	return limit(picked, n), nil
+}
+
+// RoundRobinSelector keeps a cursor per team and walks the candidates in a stable order.
+type RoundRobinSelector struct {
+	mu      sync.Mutex
+	cursors map[string]int
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{cursors: make(map[string]int)}
+}
+
+func (s *RoundRobinSelector) Select(_ context.Context, team string, candidates []string, n int) ([]string, error) {
+	if len(candidates) == 0 || n <= 0 {
+		return []string{}, nil
+	}
+
+	ordered := append([]string(nil), candidates...)
+	sort.Strings(ordered)
+	if n > len(ordered) {
+		n = len(ordered)
+	}
+
+	s.mu.Lock()
+	start := s.cursors[team] % len(ordered)
+	s.cursors[team] = start + n
+	s.mu.Unlock()
+
+	picked := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		picked = append(picked, ordered[(start+i)%len(ordered)])
+	}
+	return picked, nil
+}
+
+// LeastLoadedSelector prefers candidates with the fewest open reviews, ties are broken randomly.
+type LeastLoadedSelector struct {
+	prRepo repository.PrRepository
+}
+
+func NewLeastLoadedSelector(prRepo repository.PrRepository) *LeastLoadedSelector {
+	return &LeastLoadedSelector{prRepo: prRepo}
+}
+
+func (s *LeastLoadedSelector) Select(ctx context.Context, team string, candidates []string, n int) ([]string, error) {
+	loads, err := s.prRepo.CountOpenReviewsByTeam(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	picked := shuffled(candidates)
+	sort.SliceStable(picked, func(i, j int) bool {
+		return loads[picked[i]] < loads[picked[j]]
+	})
+	return limit(picked, n), nil
+}
+
+// WeightedSelector samples candidates without replacement proportionally to their weight.
+// Users without a configured weight get weight 1.
+type WeightedSelector struct {
+	weights map[string]int
+}
+
+func NewWeightedSelector(weights map[string]int) *WeightedSelector {
+	return &WeightedSelector{weights: weights}
+}
+
+func (s *WeightedSelector) Select(_ context.Context, _ string, candidates []string, n int) ([]string, error) {
+	keys := make(map[string]float64, len(candidates))
+	for _, id := range candidates {
+		w, ok := s.weights[id]
+		if !ok || w <= 0 {
+			w = 1
+		}
+		keys[id] = math.Pow(rand.Float64(), 1/float64(w))
+	}
+
+	picked := append([]string(nil), candidates...)
+	sort.Slice(picked, func(i, j int) bool {
+		return keys[picked[i]] > keys[picked[j]]
+	})
+	return limit(picked, n), nil
+}
+
+// PerTeamSelector routes teams with an explicit override to their own selector.
+type PerTeamSelector struct {
+	def   ReviewerSelector
+	teams map[string]ReviewerSelector
+}
+
+func NewPerTeamSelector(def ReviewerSelector, teams map[string]ReviewerSelector) *PerTeamSelector {
+	return &PerTeamSelector{def: def, teams: teams}
+}
+
+func (s *PerTeamSelector) Select(ctx context.Context, team string, candidates []string, n int) ([]string, error) {
+	if sel, ok := s.teams[team]; ok {
+		return sel.Select(ctx, team, candidates, n)
+	}
+	return s.def.Select(ctx, team, candidates, n)
+}
+
+func shuffled(list []string) []string {
+	out := append([]string(nil), list...)
+	rand.Shuffle(len(out), func(i, j int) {
+		out[i], out[j] = out[j], out[i]
+	})
+	return out
+}
+
+func limit(list []string, n int) []string {
+	if n < 0 {
+		n = 0
+	}
+	if len(list) > n {
+		return list[:n]
+	}
+	return list
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"test/internal/domain/model"
+	"test/internal/domain/repository"
+)
+
+// stubPrRepo serves fixed open review counts per team.
+type stubPrRepo struct {
+	repository.PrRepository
+	loads map[string]map[string]int
+}
+
+func (r *stubPrRepo) CountOpenReviewsByTeam(_ context.Context, team string) (map[string]int, error) {
+	return r.loads[team], nil
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	s := NewRoundRobinSelector()
+	ctx := context.Background()
+
+	calls := []struct {
+		team       string
+		candidates []string
+		n          int
+		want       []string
+	}{
+		{"backend", []string{"c", "a", "b"}, 2, []string{"a", "b"}},
+		{"backend", []string{"b", "c", "a"}, 2, []string{"c", "a"}},
+		{"frontend", []string{"y", "x"}, 1, []string{"x"}},
+		{"backend", []string{"a", "b", "c"}, 2, []string{"b", "c"}},
+		{"backend", []string{"a", "b", "c"}, 5, []string{"a", "b", "c"}},
+		{"frontend", []string{"x", "y"}, 1, []string{"y"}},
+		{"frontend", []string{"x", "y"}, 1, []string{"x"}},
+		{"backend", nil, 2, []string{}},
+	}
+	for i, c := range calls {
+		got, err := s.Select(ctx, c.team, c.candidates, c.n)
+		if err != nil {
+			t.Fatalf("call %d: Select: %v", i, err)
+		}
+		if !slices.Equal(got, c.want) {
+			t.Errorf("call %d: Select(%s, %v, %d) = %v, want %v", i, c.team, c.candidates, c.n, got, c.want)
+		}
+	}
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	repo := &stubPrRepo{loads: map[string]map[string]int{
+		"backend": {"a": 2, "b": 0, "c": 1, "d": 0},
+	}}
+	s := NewLeastLoadedSelector(repo)
+	ctx := context.Background()
+	candidates := []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{1, []string{"b", "d"}},
+		{2, []string{"b", "d"}},
+		{3, []string{"b", "c", "d"}},
+		{4, []string{"a", "b", "c", "d"}},
+	}
+	for _, tt := range tests {
+		got, err := s.Select(ctx, "backend", candidates, tt.n)
+		if err != nil {
+			t.Fatalf("Select: %v", err)
+		}
+		if len(got) != tt.n {
+			t.Fatalf("Select(n=%d) = %v, want %d reviewers", tt.n, got, tt.n)
+		}
+		for _, id := range got {
+			if !slices.Contains(tt.want, id) {
+				t.Errorf("Select(n=%d) = %v, picked %s outside the least loaded %v", tt.n, got, id, tt.want)
+			}
+		}
+		for i := 1; i < len(got); i++ {
+			if repo.loads["backend"][got[i-1]] > repo.loads["backend"][got[i]] {
+				t.Errorf("Select(n=%d) = %v, not ordered by load", tt.n, got)
+			}
+		}
+	}
+}
+
+func TestLeastLoadedSelectorBreaksTiesRandomly(t *testing.T) {
+	repo := &stubPrRepo{loads: map[string]map[string]int{"backend": {"a": 1}}}
+	s := NewLeastLoadedSelector(repo)
+
+	first := map[string]bool{}
+	for range 200 {
+		got, err := s.Select(context.Background(), "backend", []string{"a", "b", "c"}, 1)
+		if err != nil {
+			t.Fatalf("Select: %v", err)
+		}
+		first[got[0]] = true
+	}
+	if first["a"] {
+		t.Error("the loaded candidate a was picked over idle ones")
+	}
+	if !first["b"] || !first["c"] {
+		t.Errorf("picked %v over 200 runs, want both tied candidates b and c", first)
+	}
+}
+
+func TestWeightedSelector(t *testing.T) {
+	s := NewWeightedSelector(map[string]int{"a": 10, "b": 1, "c": 0})
+	candidates := []string{"a", "b", "c", "d", "e"}
+
+	tests := []struct {
+		n    int
+		want int
+	}{
+		{0, 0},
+		{1, 1},
+		{3, 3},
+		{5, 5},
+		{8, 5},
+	}
+	for _, tt := range tests {
+		for range 50 {
+			got, err := s.Select(context.Background(), "backend", candidates, tt.n)
+			if err != nil {
+				t.Fatalf("Select: %v", err)
+			}
+			if len(got) != tt.want {
+				t.Fatalf("Select(n=%d) = %v, want %d reviewers", tt.n, got, tt.want)
+			}
+			seen := map[string]bool{}
+			for _, id := range got {
+				if seen[id] || !slices.Contains(candidates, id) {
+					t.Fatalf("Select(n=%d) = %v, want distinct candidates", tt.n, got)
+				}
+				seen[id] = true
+			}
+		}
+	}
+}
+
+// recordingSelector reports which selector served a call.
+type recordingSelector struct {
+	name string
+}
+
+func (s recordingSelector) Select(context.Context, string, []string, int) ([]string, error) {
+	return []string{s.name}, nil
+}
+
+func TestSelectorForFallsBackToDefault(t *testing.T) {
+	registry := SelectorRegistry{
+		model.StrategyRoundRobin: recordingSelector{"round_robin"},
+	}
+	svc := NewPrService(nil, nil, nil, nil, registry, recordingSelector{"default"}, NopMetrics{})
+
+	tests := []struct {
+		strategy model.SelectionStrategy
+		want     string
+	}{
+		{model.StrategyRoundRobin, "round_robin"},
+		{"", "default"},
+		{"no_such_strategy", "default"},
+		{model.StrategyWeighted, "default"},
+	}
+	for _, tt := range tests {
+		sel := svc.selectorFor(&model.TeamSettings{TeamName: "backend", Strategy: tt.strategy})
+		got, _ := sel.Select(context.Background(), "backend", nil, 1)
+		if got[0] != tt.want {
+			t.Errorf("selector for %q = %s, want %s", tt.strategy, got[0], tt.want)
+		}
+	}
+}
+
+func TestSelectorRegistryHasBuiltInStrategies(t *testing.T) {
+	registry := NewSelectorRegistry(&stubPrRepo{}, nil)
+	for _, strategy := range []model.SelectionStrategy{
+		model.StrategyRandom, model.StrategyRoundRobin, model.StrategyLeastLoaded, model.StrategyWeighted,
+	} {
+		if registry[strategy] == nil {
+			t.Errorf("registry has no selector for %s", strategy)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"test/internal/domain/domain_errors"
 	"test/internal/domain/model"
 	"test/internal/domain/repository"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type TeamService struct {
@@ -21,13 +23,16 @@ func NewTeamService(teamRepo repository.TeamRepository, userRepo repository.User
 	}
 }
 
-func (s *TeamService) CreateTeam(ctx context.Context, name string, members []*model.User) (*model.Team, error) {
+func (s *TeamService) CreateTeam(ctx context.Context, name string, members []*model.User) (_ *model.Team, err error) {
+	ctx, span := startSpan(ctx, "TeamService.CreateTeam", attribute.String("team.name", name), attribute.Int("team.members", len(members)))
+	defer func() { endSpan(span, err) }()
+
 	teamObj, err := s.teamRepo.GetByName(ctx, name)
 	if err != nil {
 		return nil, err
 	}
 	if teamObj != nil {
-		return nil, domain_errors.ErrTeamExists
+		return nil, domain_errors.ErrTeamExists.With("team_name", name)
 	}
 
 	userIDs := make([]string, len(members))
@@ -51,13 +56,70 @@ func (s *TeamService) CreateTeam(ctx context.Context, name string, members []*mo
 	return team, nil
 }
 
-func (s *TeamService) GetTeam(ctx context.Context, name string) (*model.Team, error) {
+func (s *TeamService) GetTeam(ctx context.Context, name string) (_ *model.Team, err error) {
+	ctx, span := startSpan(ctx, "TeamService.GetTeam", attribute.String("team.name", name))
+	defer func() { endSpan(span, err) }()
+
 	team, err := s.teamRepo.GetByName(ctx, name)
 	if err != nil {
 		return nil, err
 	}
 	if team == nil {
-		return nil, domain_errors.ErrTeamNotFound
+		return nil, domain_errors.ErrTeamNotFound.With("team_name", name)
 	}
 	return team, nil
 }
+
+func (s *TeamService) GetSettings(ctx context.Context, name string) (_ *model.TeamSettings, err error) {
+	ctx, span := startSpan(ctx, "TeamService.GetSettings", attribute.String("team.name", name))
+	defer func() { endSpan(span, err) }()
+
+	team, err := s.teamRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, domain_errors.ErrTeamNotFound.With("team_name", name)
+	}
+
+	settings, err := s.teamRepo.GetSettings(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = model.NewDefaultTeamSettings(name)
+	}
+	return settings, nil
+}
+
+func (s *TeamService) UpdateSettings(ctx context.Context, settings *model.TeamSettings) (_ *model.TeamSettings, err error) {
+	ctx, span := startSpan(ctx, "TeamService.UpdateSettings", attribute.String("team.name", settings.TeamName))
+	defer func() { endSpan(span, err) }()
+
+	if !settings.IsValid() {
+		return nil, domain_errors.ErrInvalidTeamSettings
+	}
+
+	team, err := s.teamRepo.GetByName(ctx, settings.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, domain_errors.ErrTeamNotFound.With("team_name", settings.TeamName)
+	}
+
+	for _, name := range settings.FallbackTeams {
+		fallback, err := s.teamRepo.GetByName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if fallback == nil {
+			return nil, domain_errors.ErrFallbackTeamNotFound.With("team_name", name)
+		}
+	}
+
+	if err := s.teamRepo.SaveSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"test/internal/domain/domain_errors"
+	"test/internal/domain/model"
+	"test/internal/domain/repository"
+)
+
+// tokenPrefix marks the tokens of this service, so that a leaked one is easy
+// to recognise in logs and by secret scanners.
+const tokenPrefix = "prs_"
+
+type TokenService struct {
+	tokenRepo repository.TokenRepository
+	userRepo  repository.UserRepository
+}
+
+func NewTokenService(tokenRepo repository.TokenRepository, userRepo repository.UserRepository) *TokenService {
+	return &TokenService{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// Create issues a token and returns it together with its plain value, which is
+// not stored and cannot be retrieved later. USER tokens must name an existing
+// user; ADMIN tokens may name one.
+func (s *TokenService) Create(ctx context.Context, name string, role model.Role, userID string) (*model.APIToken, string, error) {
+	if !role.IsValid() {
+		return nil, "", domain_errors.ErrInvalidToken.With("role", string(role))
+	}
+	if role == model.RoleUser && userID == "" {
+		return nil, "", domain_errors.ErrInvalidToken.With("user_id", "required for USER tokens")
+	}
+	if userID != "" {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, "", err
+		}
+		if user == nil {
+			return nil, "", domain_errors.ErrUserNotFound.With("user_id", userID)
+		}
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, "", err
+	}
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, "", err
+	}
+
+	plain := tokenPrefix + secret
+	token := model.NewAPIToken("tok_"+id, name, role, userID, model.HashToken(plain))
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return nil, "", err
+	}
+	return token, plain, nil
+}
+
+func (s *TokenService) List(ctx context.Context) ([]*model.APIToken, error) {
+	return s.tokenRepo.List(ctx)
+}
+
+func (s *TokenService) Revoke(ctx context.Context, id string) error {
+	token, err := s.tokenRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return domain_errors.ErrTokenNotFound.With("token_id", id)
+	}
+	return s.tokenRepo.Delete(ctx, id)
+}
+
+// Authenticate returns the token with the given plain value, or nil when there
+// is none.
+func (s *TokenService) Authenticate(ctx context.Context, plain string) (*model.APIToken, error) {
+	return s.tokenRepo.GetByHash(ctx, model.HashToken(plain))
+}
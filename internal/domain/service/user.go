@@ -5,6 +5,8 @@ import (
 	"test/internal/domain/domain_errors"
 	"test/internal/domain/model"
 	"test/internal/domain/repository"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type UserService struct {
@@ -15,14 +17,22 @@ func NewUserService(repo repository.UserRepository) *UserService {
 	return &UserService{userRepo: repo}
 }
 
-func (s *UserService) SetIsActive(ctx context.Context, id string, active bool) (*model.User, error) {
+// SetIsActive activates or deactivates a user. A non-zero version must match the
+// current version of the user.
+func (s *UserService) SetIsActive(ctx context.Context, id string, active bool, version int64) (_ *model.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.SetIsActive", attribute.String("user.id", id), attribute.Bool("user.is_active", active))
+	defer func() { endSpan(span, err) }()
+
 	u, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	if u == nil {
-		return nil, domain_errors.ErrUserNotFound
+		return nil, domain_errors.ErrUserNotFound.With("user_id", id)
+	}
+	if err := u.CheckVersion(version); err != nil {
+		return nil, err
 	}
 
 	if active {
@@ -30,6 +40,9 @@ func (s *UserService) SetIsActive(ctx context.Context, id string, active bool) (
 	} else {
 		u.Deactivate()
 	}
+	if len(u.PendingEvents()) == 0 {
+		return u, nil
+	}
 
 	if err := s.userRepo.Save(ctx, u); err != nil {
 		return nil, err
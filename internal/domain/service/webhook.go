@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"test/internal/domain/domain_errors"
+	"test/internal/domain/model"
+	"test/internal/domain/repository"
+)
+
+type WebhookService struct {
+	webhookRepo repository.WebhookRepository
+}
+
+func NewWebhookService(repo repository.WebhookRepository) *WebhookService {
+	return &WebhookService{webhookRepo: repo}
+}
+
+// Subscribe registers an endpoint for the given event types. A secret is
+// generated when none is provided.
+func (s *WebhookService) Subscribe(ctx context.Context, rawURL, secret string, eventTypes []string) (*model.WebhookSubscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, domain_errors.ErrInvalidWebhook.With("url", "must be an absolute http(s) URL")
+	}
+	for _, t := range eventTypes {
+		if !model.IsKnownEventName(t) {
+			return nil, domain_errors.ErrInvalidWebhook.With("event_type", t)
+		}
+	}
+
+	if secret == "" {
+		if secret, err = randomHex(32); err != nil {
+			return nil, err
+		}
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := model.NewWebhookSubscription("wh_"+id, rawURL, secret, eventTypes)
+	if err := s.webhookRepo.Create(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s *WebhookService) List(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	return s.webhookRepo.List(ctx)
+}
+
+func (s *WebhookService) Unsubscribe(ctx context.Context, id string) error {
+	sub, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if sub == nil {
+		return domain_errors.ErrWebhookNotFound.With("webhook_id", id)
+	}
+	return s.webhookRepo.Delete(ctx, id)
+}
+
+// GetDeliveries lists deliveries of a subscription, optionally filtered by status.
+func (s *WebhookService) GetDeliveries(ctx context.Context, id string, status model.DeliveryStatus) ([]*model.WebhookDelivery, error) {
+	sub, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, domain_errors.ErrWebhookNotFound.With("webhook_id", id)
+	}
+	return s.webhookRepo.GetDeliveries(ctx, id, status)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
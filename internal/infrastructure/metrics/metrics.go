@@ -0,0 +1,175 @@
+// Package metrics exposes HTTP and pull request metrics in the Prometheus format.
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_service"
+
+// OpenReviewsFunc returns the number of open reviews assigned to each user.
+type OpenReviewsFunc func(ctx context.Context) (map[string]int, error)
+
+// Metrics implements service.Metrics and serves everything on its own registry.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	prsCreated    *prometheus.CounterVec
+	prsMerged     *prometheus.CounterVec
+	timeToMerge   *prometheus.HistogramVec
+	reassignments *prometheus.CounterVec
+	noCandidate   *prometheus.CounterVec
+}
+
+// New registers all metrics. openReviews is queried on every scrape.
+func New(openReviews OpenReviewsFunc) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route pattern and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		prsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pull_requests_created_total",
+			Help:      "Pull requests created, by author team.",
+		}, []string{"team"}),
+		prsMerged: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pull_requests_merged_total",
+			Help:      "Pull requests merged, by author team.",
+		}, []string{"team"}),
+		timeToMerge: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "pull_request_time_to_merge_seconds",
+			Help:      "Time from creation to merge of pull requests, by author team.",
+			// 1 minute to about 3 weeks.
+			Buckets: prometheus.ExponentialBuckets(60, 4, 9),
+		}, []string{"team"}),
+		reassignments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewer_reassignments_total",
+			Help:      "Successful reviewer reassignments, by author team.",
+		}, []string{"team"}),
+		noCandidate: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewer_no_replacement_candidate_total",
+			Help:      "Reassignments that failed because no replacement candidate was found, by author team.",
+		}, []string{"team"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration,
+		m.prsCreated, m.prsMerged, m.timeToMerge, m.reassignments, m.noCandidate,
+		&openReviewsCollector{
+			fetch: openReviews,
+			desc: prometheus.NewDesc(namespace+"_open_reviews",
+				"Open pull requests each user is assigned to review.", []string{"user_id"}, nil),
+		},
+	)
+	return m
+}
+
+// Handler serves the metrics for scraping.
+func (m *Metrics) Handler() http.Handler {
+	// A failing collector drops only its own metrics from the scrape.
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+}
+
+// Middleware counts requests and measures their latency. It labels them with
+// the chi route pattern rather than the path, so ids do not blow up cardinality.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		status := strconv.Itoa(rec.status)
+
+		m.httpRequests.WithLabelValues(r.Method, route, status).Inc()
+		m.httpDuration.WithLabelValues(r.Method, route, status).Observe(time.Since(start).Seconds())
+	})
+}
+
+func (m *Metrics) PrCreated(team string) {
+	m.prsCreated.WithLabelValues(team).Inc()
+}
+
+func (m *Metrics) PrMerged(team string, timeToMerge time.Duration) {
+	m.prsMerged.WithLabelValues(team).Inc()
+	m.timeToMerge.WithLabelValues(team).Observe(timeToMerge.Seconds())
+}
+
+func (m *Metrics) ReviewerReassigned(team string) {
+	m.reassignments.WithLabelValues(team).Inc()
+}
+
+func (m *Metrics) NoReplacementCandidate(team string) {
+	m.noCandidate.WithLabelValues(team).Inc()
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// openReviewsCollector reads the open review counts at scrape time, so the
+// gauge is always in line with the database.
+type openReviewsCollector struct {
+	fetch OpenReviewsFunc
+	desc  *prometheus.Desc
+}
+
+func (c *openReviewsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *openReviewsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	counts, err := c.fetch(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "metrics: count open reviews", "error", err)
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	for userID, n := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(n), userID)
+	}
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"test/internal/domain/model"
+	"time"
+)
+
+type IdempotencyRepository struct {
+	s *Store
+}
+
+func NewIdempotencyRepository(s *Store) *IdempotencyRepository {
+	return &IdempotencyRepository{s: s}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, rec *model.IdempotencyRecord) (existing *model.IdempotencyRecord, err error) {
+	err = r.s.write(ctx, func(t *tx) error {
+		// An expired or abandoned record is taken over as if it did not exist.
+		if stored, ok := r.s.idempotency[rec.Key]; ok && !stored.Available(time.Now()) {
+			existing = cloneIdempotencyRecord(stored)
+			return nil
+		}
+
+		c := cloneIdempotencyRecord(rec)
+		c.StatusCode, c.ContentType, c.ETag, c.Body = 0, "", "", nil
+		put(t, r.s.idempotency, c.Key, c)
+		return nil
+	})
+	return existing, err
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, rec *model.IdempotencyRecord) error {
+	return r.s.write(ctx, func(t *tx) error {
+		stored, ok := r.s.idempotency[rec.Key]
+		if !ok || stored.RequestHash != rec.RequestHash {
+			return nil
+		}
+
+		c := cloneIdempotencyRecord(stored)
+		c.StatusCode = rec.StatusCode
+		c.ContentType = rec.ContentType
+		c.ETag = rec.ETag
+		c.Body = append([]byte(nil), rec.Body...)
+		put(t, r.s.idempotency, c.Key, c)
+		return nil
+	})
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	return r.s.write(ctx, func(t *tx) error {
+		if stored, ok := r.s.idempotency[key]; ok && !stored.Completed() {
+			remove(t, r.s.idempotency, key)
+		}
+		return nil
+	})
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context) (n int64, err error) {
+	err = r.s.write(ctx, func(t *tx) error {
+		now := time.Now()
+		for key, rec := range r.s.idempotency {
+			if !rec.ExpiresAt.After(now) {
+				remove(t, r.s.idempotency, key)
+				n++
+			}
+		}
+		return nil
+	})
+	return n, err
+}
+
+func cloneIdempotencyRecord(rec *model.IdempotencyRecord) *model.IdempotencyRecord {
+	c := *rec
+	c.Body = append([]byte(nil), rec.Body...)
+	return &c
+}
@@ -0,0 +1,21 @@
+package memory_test
+
+import (
+	"test/internal/infrastructure/persistence/memory"
+	"test/internal/infrastructure/persistence/repotest"
+	"testing"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		store := memory.NewStore()
+		userRepo := memory.NewUserRepository(store)
+		return repotest.Repositories{
+			User:        userRepo,
+			Team:        memory.NewTeamRepository(store, userRepo),
+			Pr:          memory.NewPrRepository(store),
+			Tx:          memory.NewTxManager(store),
+			Idempotency: memory.NewIdempotencyRepository(store),
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"test/internal/domain/model"
+	"time"
+)
+
+type OutboxRepository struct {
+	s *Store
+}
+
+func NewOutboxRepository(s *Store) *OutboxRepository {
+	return &OutboxRepository{s: s}
+}
+
+func (r *OutboxRepository) FanOut(ctx context.Context, limit int) (n int, err error) {
+	err = r.s.write(ctx, func(t *tx) error {
+		var entries []*outboxEntry
+		for _, e := range r.s.outbox {
+			if !e.dispatched {
+				entries = append(entries, e)
+			}
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].id < entries[j].id
+		})
+		if len(entries) > limit {
+			entries = entries[:limit]
+		}
+
+		for _, e := range entries {
+			for _, sub := range r.s.webhooks {
+				if !subscribed(sub, e.msg.EventType) {
+					continue
+				}
+				id := r.s.nextID()
+				put(t, r.s.deliveries, id, &model.WebhookDelivery{
+					ID:             id,
+					EventID:        e.id,
+					SubscriptionID: sub.ID,
+					Status:         model.DeliveryPending,
+					NextAttemptAt:  time.Now(),
+				})
+			}
+
+			dispatched := *e
+			dispatched.dispatched = true
+			put(t, r.s.outbox, e.id, &dispatched)
+		}
+
+		n = len(entries)
+		return nil
+	})
+	return n, err
+}
+
+func subscribed(sub *model.WebhookSubscription, eventType string) bool {
+	if len(sub.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range sub.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *OutboxRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	var claimed []*model.WebhookDelivery
+	err := r.s.write(ctx, func(t *tx) error {
+		now := time.Now()
+
+		var due []*model.WebhookDelivery
+		for _, d := range r.s.deliveries {
+			if d.Status == model.DeliveryPending && !d.NextAttemptAt.After(now) {
+				due = append(due, d)
+			}
+		}
+		sort.Slice(due, func(i, j int) bool {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		})
+		if len(due) > limit {
+			due = due[:limit]
+		}
+
+		for _, d := range due {
+			leased := *d
+			leased.NextAttemptAt = now.Add(lease)
+			put(t, r.s.deliveries, d.ID, &leased)
+			claimed = append(claimed, r.s.joinDelivery(&leased))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id int64) error {
+	return r.update(ctx, id, func(d *model.WebhookDelivery) {
+		now := time.Now()
+		d.Status = model.DeliveryDelivered
+		d.Attempts++
+		d.DeliveredAt = &now
+		d.LastError = ""
+	})
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt *time.Time) error {
+	return r.update(ctx, id, func(d *model.WebhookDelivery) {
+		d.Attempts++
+		d.LastError = lastError
+		if nextAttemptAt == nil {
+			d.Status = model.DeliveryDead
+		} else {
+			d.NextAttemptAt = *nextAttemptAt
+		}
+	})
+}
+
+// update replaces the delivery with a changed copy. A missing delivery is left
+// alone, as an UPDATE matching no rows would.
+func (r *OutboxRepository) update(ctx context.Context, id int64, change func(d *model.WebhookDelivery)) error {
+	return r.s.write(ctx, func(t *tx) error {
+		d, ok := r.s.deliveries[id]
+		if !ok {
+			return nil
+		}
+		c := *d
+		change(&c)
+		put(t, r.s.deliveries, id, &c)
+		return nil
+	})
+}
@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"test/internal/domain/domain_errors"
+	"test/internal/domain/model"
+	"test/internal/domain/repository"
+)
+
+type PrRepository struct {
+	s *Store
+}
+
+func NewPrRepository(s *Store) *PrRepository {
+	return &PrRepository{s: s}
+}
+
+func (r *PrRepository) GetByID(_ context.Context, id string) (*model.PullRequest, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	pr, ok := r.s.prs[id]
+	if !ok {
+		return nil, nil
+	}
+	return clonePr(pr), nil
+}
+
+// GetByIDForUpdate is GetByID: units of work already run one at a time.
+func (r *PrRepository) GetByIDForUpdate(ctx context.Context, id string) (*model.PullRequest, error) {
+	return r.GetByID(ctx, id)
+}
+
+// Create stores a new pull request with its events. It returns
+// ErrPullRequestExists instead of overwriting a pull request with the same id.
+func (r *PrRepository) Create(ctx context.Context, pr *model.PullRequest) error {
+	err := r.s.write(ctx, func(t *tx) error {
+		if _, ok := r.s.prs[pr.ID]; ok {
+			return domain_errors.ErrPullRequestExists
+		}
+		return r.store(t, pr, pr.Version)
+	})
+	if err != nil {
+		return err
+	}
+
+	pr.ClearPendingEvents()
+	return nil
+}
+
+// Save updates an existing pull request together with its events and
+// increments its version. It returns ErrVersionMismatch if the stored version
+// differs from pr.Version.
+func (r *PrRepository) Save(ctx context.Context, pr *model.PullRequest) error {
+	err := r.s.write(ctx, func(t *tx) error {
+		stored, ok := r.s.prs[pr.ID]
+		if !ok {
+			return domain_errors.ErrPullRequestNotFound
+		}
+		if stored.Version != pr.Version {
+			return domain_errors.ErrVersionMismatch
+		}
+		return r.store(t, pr, pr.Version+1)
+	})
+	if err != nil {
+		return err
+	}
+
+	pr.Version++
+	pr.ClearPendingEvents()
+	return nil
+}
+
+// store writes a copy of pr with the given version, its pending events and
+// their outbox messages. Callers hold mu.
+func (r *PrRepository) store(t *tx, pr *model.PullRequest, version int64) error {
+	if _, ok := r.s.users[pr.AuthorID]; !ok {
+		return repository.ErrForeignKeyViolation
+	}
+	for _, id := range pr.AssignedReviewers {
+		if _, ok := r.s.users[id]; !ok {
+			return repository.ErrForeignKeyViolation
+		}
+	}
+
+	msgs := make([]*model.OutboxMessage, 0, len(pr.PendingEvents()))
+	for _, e := range pr.PendingEvents() {
+		msg, err := model.NewPrOutboxMessage(pr, e)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+
+	c := clonePr(pr)
+	c.Version = version
+	put(t, r.s.prs, c.ID, c)
+
+	for _, e := range pr.PendingEvents() {
+		event := *e
+		event.ID = r.s.nextID()
+		put(t, r.s.prEvents, event.ID, &event)
+	}
+	r.s.enqueue(t, msgs)
+	return nil
+}
+
+func (r *PrRepository) GetByReviewer(_ context.Context, reviewerID string) ([]*model.PullRequest, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var result []*model.PullRequest
+	for _, pr := range r.s.prs {
+		if pr.HasReviewer(reviewerID) {
+			result = append(result, clonePr(pr))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func (r *PrRepository) CheckUserOpenPRs(_ context.Context, userIDs []string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	ids := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		ids[id] = true
+	}
+
+	for _, pr := range r.s.prs {
+		if ids[pr.AuthorID] && (pr.Status == model.StatusOpen || pr.Status == model.StatusDraft) {
+			return true, nil
+		}
+		if pr.Status != model.StatusOpen {
+			continue
+		}
+		for _, id := range pr.AssignedReviewers {
+			if ids[id] {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r *PrRepository) CountOpenReviewsByTeam(_ context.Context, team string) (map[string]int, error) {
+	return r.countOpenReviews(func(u *model.User) bool { return u.TeamName == team }), nil
+}
+
+func (r *PrRepository) CountOpenReviews(_ context.Context) (map[string]int, error) {
+	return r.countOpenReviews(func(*model.User) bool { return true }), nil
+}
+
+func (r *PrRepository) countOpenReviews(include func(u *model.User) bool) map[string]int {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, u := range r.s.users {
+		if include(u) {
+			counts[u.ID] = 0
+		}
+	}
+
+	for _, pr := range r.s.prs {
+		if pr.Status != model.StatusOpen {
+			continue
+		}
+		for _, id := range pr.AssignedReviewers {
+			if _, ok := counts[id]; ok {
+				counts[id]++
+			}
+		}
+	}
+	return counts
+}
+
+func (r *PrRepository) GetHistory(_ context.Context, prID string) ([]*model.PrEvent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	events := []*model.PrEvent{}
+	for _, e := range r.s.prEvents {
+		if e.PullRequestID == prID {
+			event := *e
+			events = append(events, &event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+	return events, nil
+}
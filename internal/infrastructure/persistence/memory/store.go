@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"test/internal/domain/model"
+)
+
+// Store holds the data of all in-memory repositories. Writes are serialized
+// with each other and with units of work run through TxManager, and the writes
+// of a failed unit of work are undone.
+type Store struct {
+	// txMu serializes writers, mu guards the maps below.
+	txMu sync.Mutex
+	mu   sync.RWMutex
+
+	teams    map[string]struct{}
+	users    map[string]*model.User
+	settings map[string]*model.TeamSettings
+
+	prs      map[string]*model.PullRequest
+	prEvents map[int64]*model.PrEvent
+
+	outbox      map[int64]*outboxEntry
+	webhooks    map[string]*model.WebhookSubscription
+	deliveries  map[int64]*model.WebhookDelivery
+	idempotency map[string]*model.IdempotencyRecord
+	tokens      map[string]*model.APIToken
+
+	lastID int64
+}
+
+type outboxEntry struct {
+	id         int64
+	msg        *model.OutboxMessage
+	dispatched bool
+}
+
+func NewStore() *Store {
+	return &Store{
+		teams:       make(map[string]struct{}),
+		users:       make(map[string]*model.User),
+		settings:    make(map[string]*model.TeamSettings),
+		prs:         make(map[string]*model.PullRequest),
+		prEvents:    make(map[int64]*model.PrEvent),
+		outbox:      make(map[int64]*outboxEntry),
+		webhooks:    make(map[string]*model.WebhookSubscription),
+		deliveries:  make(map[int64]*model.WebhookDelivery),
+		idempotency: make(map[string]*model.IdempotencyRecord),
+		tokens:      make(map[string]*model.APIToken),
+	}
+}
+
+type txKey struct{}
+
+// tx collects the undo steps of the writes made in a unit of work.
+type tx struct {
+	undo []func()
+}
+
+func (t *tx) onRollback(fn func()) {
+	t.undo = append(t.undo, fn)
+}
+
+func (s *Store) withinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*tx); ok {
+		return fn(ctx)
+	}
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	t := &tx{}
+	defer func() {
+		if p := recover(); p != nil {
+			s.rollback(t)
+			panic(p)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, t)); err != nil {
+		s.rollback(t)
+	}
+	return err
+}
+
+func (s *Store) rollback(t *tx) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		t.undo[i]()
+	}
+}
+
+// write runs fn with the store locked for writing, as part of the unit of work
+// carried by ctx or, outside of one, as a unit of work of its own.
+func (s *Store) write(ctx context.Context, fn func(t *tx) error) error {
+	return s.withinTx(ctx, func(ctx context.Context) error {
+		t := ctx.Value(txKey{}).(*tx)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return fn(t)
+	})
+}
+
+func (s *Store) nextID() int64 {
+	s.lastID++
+	return s.lastID
+}
+
+func put[K comparable, V any](t *tx, m map[K]V, k K, v V) {
+	old, existed := m[k]
+	m[k] = v
+	t.onRollback(func() {
+		if existed {
+			m[k] = old
+		} else {
+			delete(m, k)
+		}
+	})
+}
+
+func remove[K comparable, V any](t *tx, m map[K]V, k K) {
+	old, existed := m[k]
+	if !existed {
+		return
+	}
+	delete(m, k)
+	t.onRollback(func() {
+		m[k] = old
+	})
+}
+
+// enqueue adds messages to the outbox. Callers hold mu.
+func (s *Store) enqueue(t *tx, msgs []*model.OutboxMessage) {
+	for _, msg := range msgs {
+		id := s.nextID()
+		put(t, s.outbox, id, &outboxEntry{id: id, msg: msg})
+	}
+}
+
+// The stored values are copies, so callers can neither change the store
+// through a returned value nor through a value they saved.
+
+func cloneUser(u *model.User) *model.User {
+	c := model.NewUser(u.ID, u.Username, u.TeamName, u.IsActive)
+	c.Version = u.Version
+	return c
+}
+
+func clonePr(pr *model.PullRequest) *model.PullRequest {
+	c := &model.PullRequest{
+		ID:                pr.ID,
+		Name:              pr.Name,
+		AuthorID:          pr.AuthorID,
+		Status:            pr.Status,
+		AssignedReviewers: append([]string{}, pr.AssignedReviewers...),
+		FallbackReviewers: make(map[string]string, len(pr.FallbackReviewers)),
+		Reviews:           make(map[string]*model.Review, len(pr.Reviews)),
+		CreatedAt:         pr.CreatedAt,
+		Version:           pr.Version,
+	}
+	for id, team := range pr.FallbackReviewers {
+		c.FallbackReviewers[id] = team
+	}
+	for id, r := range pr.Reviews {
+		review := *r
+		c.Reviews[id] = &review
+	}
+	if pr.MergedAt != nil {
+		mergedAt := *pr.MergedAt
+		c.MergedAt = &mergedAt
+	}
+	if pr.MergeOverrideReason != nil {
+		reason := *pr.MergeOverrideReason
+		c.MergeOverrideReason = &reason
+	}
+	return c
+}
+
+func cloneSettings(s *model.TeamSettings) *model.TeamSettings {
+	c := *s
+	c.FallbackTeams = append([]string{}, s.FallbackTeams...)
+	return &c
+}
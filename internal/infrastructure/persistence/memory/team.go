@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"test/internal/domain/domain_errors"
+	"test/internal/domain/model"
+	"test/internal/domain/repository"
+)
+
+type TeamRepository struct {
+	s        *Store
+	userRepo *UserRepository
+}
+
+func NewTeamRepository(s *Store, userRepo *UserRepository) *TeamRepository {
+	return &TeamRepository{s: s, userRepo: userRepo}
+}
+
+// Create inserts the team and its members. It returns ErrTeamExists when a team
+// with the same name already exists.
+func (r *TeamRepository) Create(ctx context.Context, team *model.Team) error {
+	return r.s.write(ctx, func(t *tx) error {
+		if _, ok := r.s.teams[team.Name]; ok {
+			return domain_errors.ErrTeamExists
+		}
+		put(t, r.s.teams, team.Name, struct{}{})
+
+		for _, u := range team.Members {
+			u.TeamName = team.Name
+			if err := r.userRepo.upsert(t, u); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *TeamRepository) GetByName(ctx context.Context, name string) (*model.Team, error) {
+	r.s.mu.RLock()
+	_, ok := r.s.teams[name]
+	r.s.mu.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+
+	users, err := r.userRepo.GetByTeam(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewTeam(name, users), nil
+}
+
+func (r *TeamRepository) GetSettings(_ context.Context, team string) (*model.TeamSettings, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	settings, ok := r.s.settings[team]
+	if !ok {
+		return nil, nil
+	}
+	return cloneSettings(settings), nil
+}
+
+func (r *TeamRepository) SaveSettings(ctx context.Context, settings *model.TeamSettings) error {
+	return r.s.write(ctx, func(t *tx) error {
+		if _, ok := r.s.teams[settings.TeamName]; !ok {
+			return repository.ErrForeignKeyViolation
+		}
+		for _, name := range settings.FallbackTeams {
+			if _, ok := r.s.teams[name]; !ok {
+				return repository.ErrForeignKeyViolation
+			}
+		}
+
+		put(t, r.s.settings, settings.TeamName, cloneSettings(settings))
+		return nil
+	})
+}
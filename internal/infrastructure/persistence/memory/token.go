@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"test/internal/domain/model"
+	"test/internal/domain/repository"
+)
+
+type TokenRepository struct {
+	s *Store
+}
+
+func NewTokenRepository(s *Store) *TokenRepository {
+	return &TokenRepository{s: s}
+}
+
+func (r *TokenRepository) Create(ctx context.Context, token *model.APIToken) error {
+	return r.s.write(ctx, func(t *tx) error {
+		if _, ok := r.s.tokens[token.ID]; ok {
+			return ErrDuplicateKey
+		}
+		for _, stored := range r.s.tokens {
+			if stored.Hash == token.Hash {
+				return ErrDuplicateKey
+			}
+		}
+		if token.UserID != "" {
+			if _, ok := r.s.users[token.UserID]; !ok {
+				return repository.ErrForeignKeyViolation
+			}
+		}
+		c := *token
+		put(t, r.s.tokens, token.ID, &c)
+		return nil
+	})
+}
+
+func (r *TokenRepository) GetByID(_ context.Context, id string) (*model.APIToken, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	token, ok := r.s.tokens[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *token
+	return &c, nil
+}
+
+func (r *TokenRepository) GetByHash(_ context.Context, hash string) (*model.APIToken, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, token := range r.s.tokens {
+		if token.Hash == hash {
+			c := *token
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *TokenRepository) List(_ context.Context) ([]*model.APIToken, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	tokens := []*model.APIToken{}
+	for _, token := range r.s.tokens {
+		c := *token
+		tokens = append(tokens, &c)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt.Before(tokens[j].CreatedAt)
+	})
+	return tokens, nil
+}
+
+func (r *TokenRepository) Delete(ctx context.Context, id string) error {
+	return r.s.write(ctx, func(t *tx) error {
+		remove(t, r.s.tokens, id)
+		return nil
+	})
+}
@@ -0,0 +1,15 @@
+package memory
+
+import "context"
+
+type TxManager struct {
+	s *Store
+}
+
+func NewTxManager(s *Store) *TxManager {
+	return &TxManager{s: s}
+}
+
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.s.withinTx(ctx, fn)
+}
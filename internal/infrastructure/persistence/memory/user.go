@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"test/internal/domain/domain_errors"
+	"test/internal/domain/model"
+	"test/internal/domain/repository"
+)
+
+type UserRepository struct {
+	s *Store
+}
+
+func NewUserRepository(s *Store) *UserRepository {
+	return &UserRepository{s: s}
+}
+
+func (r *UserRepository) GetByID(_ context.Context, id string) (*model.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	u, ok := r.s.users[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneUser(u), nil
+}
+
+func (r *UserRepository) GetByTeam(_ context.Context, team string) ([]*model.User, error) {
+	return r.byTeam(team, false), nil
+}
+
+func (r *UserRepository) GetActiveByTeam(_ context.Context, team string) ([]*model.User, error) {
+	return r.byTeam(team, true), nil
+}
+
+func (r *UserRepository) byTeam(team string, activeOnly bool) []*model.User {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var users []*model.User
+	for _, u := range r.s.users {
+		if u.TeamName == team && (!activeOnly || u.IsActive) {
+			users = append(users, cloneUser(u))
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+	return users
+}
+
+// Save updates an existing user and increments its version. It returns
+// ErrVersionMismatch if the stored version differs from u.Version.
+func (r *UserRepository) Save(ctx context.Context, u *model.User) error {
+	err := r.s.write(ctx, func(t *tx) error {
+		stored, ok := r.s.users[u.ID]
+		if !ok {
+			return domain_errors.ErrUserNotFound
+		}
+		if stored.Version != u.Version {
+			return domain_errors.ErrVersionMismatch
+		}
+		if _, ok := r.s.teams[u.TeamName]; !ok {
+			return repository.ErrForeignKeyViolation
+		}
+
+		msgs, err := userOutboxMessages(u)
+		if err != nil {
+			return err
+		}
+
+		c := cloneUser(u)
+		c.Version++
+		put(t, r.s.users, c.ID, c)
+		r.s.enqueue(t, msgs)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	u.Version++
+	u.ClearPendingEvents()
+	return nil
+}
+
+// upsert inserts or overwrites u regardless of its version. Callers hold mu.
+func (r *UserRepository) upsert(t *tx, u *model.User) error {
+	if _, ok := r.s.teams[u.TeamName]; !ok {
+		return repository.ErrForeignKeyViolation
+	}
+
+	msgs, err := userOutboxMessages(u)
+	if err != nil {
+		return err
+	}
+
+	c := cloneUser(u)
+	c.Version = 1
+	if stored, ok := r.s.users[u.ID]; ok {
+		c.Version = stored.Version + 1
+	}
+	put(t, r.s.users, c.ID, c)
+	r.s.enqueue(t, msgs)
+
+	u.Version = c.Version
+	return nil
+}
+
+func userOutboxMessages(u *model.User) ([]*model.OutboxMessage, error) {
+	msgs := make([]*model.OutboxMessage, 0, len(u.PendingEvents()))
+	for _, e := range u.PendingEvents() {
+		msg, err := model.NewUserOutboxMessage(u, e)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
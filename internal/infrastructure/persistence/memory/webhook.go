@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"test/internal/domain/model"
+)
+
+// ErrDuplicateKey is returned when a row with the same primary key exists.
+var ErrDuplicateKey = errors.New("memory: duplicate key")
+
+type WebhookRepository struct {
+	s *Store
+}
+
+func NewWebhookRepository(s *Store) *WebhookRepository {
+	return &WebhookRepository{s: s}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, sub *model.WebhookSubscription) error {
+	return r.s.write(ctx, func(t *tx) error {
+		if _, ok := r.s.webhooks[sub.ID]; ok {
+			return ErrDuplicateKey
+		}
+		put(t, r.s.webhooks, sub.ID, cloneSubscription(sub))
+		return nil
+	})
+}
+
+func (r *WebhookRepository) GetByID(_ context.Context, id string) (*model.WebhookSubscription, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	sub, ok := r.s.webhooks[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneSubscription(sub), nil
+}
+
+func (r *WebhookRepository) List(_ context.Context) ([]*model.WebhookSubscription, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	subs := []*model.WebhookSubscription{}
+	for _, sub := range r.s.webhooks {
+		subs = append(subs, cloneSubscription(sub))
+	}
+	sort.Slice(subs, func(i, j int) bool {
+		return subs[i].CreatedAt.Before(subs[j].CreatedAt)
+	})
+	return subs, nil
+}
+
+// Delete removes the subscription together with its deliveries.
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	return r.s.write(ctx, func(t *tx) error {
+		remove(t, r.s.webhooks, id)
+		for deliveryID, d := range r.s.deliveries {
+			if d.SubscriptionID == id {
+				remove(t, r.s.deliveries, deliveryID)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *WebhookRepository) GetDeliveries(_ context.Context, subscriptionID string, status model.DeliveryStatus) ([]*model.WebhookDelivery, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	deliveries := []*model.WebhookDelivery{}
+	for _, d := range r.s.deliveries {
+		if d.SubscriptionID != subscriptionID || (status != "" && d.Status != status) {
+			continue
+		}
+		c := r.s.joinDelivery(d)
+		c.Secret = ""
+		deliveries = append(deliveries, c)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].ID > deliveries[j].ID
+	})
+	if len(deliveries) > 100 {
+		deliveries = deliveries[:100]
+	}
+	return deliveries, nil
+}
+
+// joinDelivery returns a copy of d with the event and subscription fields
+// filled in. Callers hold mu.
+func (s *Store) joinDelivery(d *model.WebhookDelivery) *model.WebhookDelivery {
+	c := *d
+	if e, ok := s.outbox[d.EventID]; ok {
+		c.EventType = e.msg.EventType
+		c.Payload = append([]byte(nil), e.msg.Payload...)
+	}
+	if sub, ok := s.webhooks[d.SubscriptionID]; ok {
+		c.URL = sub.URL
+		c.Secret = sub.Secret
+	}
+	if d.DeliveredAt != nil {
+		deliveredAt := *d.DeliveredAt
+		c.DeliveredAt = &deliveredAt
+	}
+	return &c
+}
+
+func cloneSubscription(sub *model.WebhookSubscription) *model.WebhookSubscription {
+	c := *sub
+	c.EventTypes = append([]string{}, sub.EventTypes...)
+	return &c
+}
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS team_settings (
+                              team_name TEXT PRIMARY KEY REFERENCES teams(name) ON DELETE CASCADE,
+                              reviewers_per_pr INTEGER NOT NULL DEFAULT 2 CHECK (reviewers_per_pr >= 0),
+                              selection_strategy TEXT CHECK (selection_strategy IN ('random', 'round_robin', 'least_loaded', 'weighted')),
+                              allow_cross_team BOOLEAN NOT NULL DEFAULT FALSE
+);
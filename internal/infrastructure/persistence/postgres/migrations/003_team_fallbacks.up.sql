@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS team_fallbacks (
+                              team_name TEXT NOT NULL REFERENCES team_settings(team_name) ON DELETE CASCADE,
+                              fallback_team TEXT NOT NULL REFERENCES teams(name) ON DELETE CASCADE,
+                              position INTEGER NOT NULL,
+                              PRIMARY KEY(team_name, fallback_team),
+                              CHECK (team_name <> fallback_team)
+);
+
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS fallback_team TEXT;
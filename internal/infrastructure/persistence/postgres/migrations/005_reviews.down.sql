@@ -0,0 +1,7 @@
+ALTER TABLE team_settings DROP COLUMN IF EXISTS min_approvals;
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS merge_override_reason;
+
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS decided_at,
+    DROP COLUMN IF EXISTS decision;
@@ -0,0 +1,7 @@
+ALTER TABLE pr_reviewers
+    ADD COLUMN IF NOT EXISTS decision TEXT CHECK (decision IN ('APPROVED', 'CHANGES_REQUESTED')),
+    ADD COLUMN IF NOT EXISTS decided_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS merge_override_reason TEXT;
+
+ALTER TABLE team_settings ADD COLUMN IF NOT EXISTS min_approvals INTEGER NOT NULL DEFAULT 0 CHECK (min_approvals >= 0);
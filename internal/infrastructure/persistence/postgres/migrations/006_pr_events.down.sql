@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pr_events_pr;
+
+DROP TABLE IF EXISTS pr_events;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS pr_events (
+                           id BIGSERIAL PRIMARY KEY,
+                           pr_id TEXT NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+                           type TEXT NOT NULL,
+                           reviewer_id TEXT,
+                           old_reviewer_id TEXT,
+                           from_status TEXT,
+                           to_status TEXT,
+                           details TEXT,
+                           created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_events_pr ON pr_events(pr_id, id);
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS outbox (
+                        id BIGSERIAL PRIMARY KEY,
+                        aggregate_type TEXT NOT NULL,
+                        aggregate_id TEXT NOT NULL,
+                        event_type TEXT NOT NULL,
+                        payload JSONB NOT NULL,
+                        created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+                        dispatched_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_undispatched ON outbox(id) WHERE dispatched_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+                                       id TEXT PRIMARY KEY,
+                                       url TEXT NOT NULL,
+                                       secret TEXT NOT NULL,
+                                       event_types TEXT[] NOT NULL DEFAULT '{}',
+                                       created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+                                    id BIGSERIAL PRIMARY KEY,
+                                    outbox_id BIGINT NOT NULL REFERENCES outbox(id) ON DELETE CASCADE,
+                                    subscription_id TEXT NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+                                    status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'DELIVERED', 'DEAD')),
+                                    attempts INTEGER NOT NULL DEFAULT 0,
+                                    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+                                    last_error TEXT,
+                                    delivered_at TIMESTAMP WITH TIME ZONE,
+                                    UNIQUE(outbox_id, subscription_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+                                  key TEXT PRIMARY KEY,
+                                  request_hash TEXT NOT NULL,
+                                  status_code INTEGER,
+                                  content_type TEXT NOT NULL DEFAULT '',
+                                  response_body BYTEA,
+                                  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+                                  expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
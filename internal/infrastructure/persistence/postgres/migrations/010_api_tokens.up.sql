@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS api_tokens (
+                            id TEXT PRIMARY KEY,
+                            name TEXT NOT NULL,
+                            role TEXT NOT NULL CHECK (role IN ('ADMIN', 'USER')),
+                            user_id TEXT REFERENCES users(id) ON DELETE CASCADE,
+                            token_hash TEXT NOT NULL UNIQUE,
+                            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+                            CHECK (role <> 'USER' OR user_id IS NOT NULL)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+ALTER TABLE idempotency_keys ALTER COLUMN locked_until DROP DEFAULT;
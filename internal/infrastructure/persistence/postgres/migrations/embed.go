@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations of the service. Files follow the
+// golang-migrate naming scheme: <version>_<name>.up.sql and <version>_<name>.down.sql.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
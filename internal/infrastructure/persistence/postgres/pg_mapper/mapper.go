@@ -1,6 +1,7 @@
 package pg_mapper
 
 import (
+	"database/sql"
 	"test/internal/domain/model"
 	"test/internal/infrastructure/persistence/postgres/pg_model"
 )
@@ -11,6 +12,7 @@ func MapUserToUserDb(u *model.User) *pg_model.UserDb {
 		Username: u.Username,
 		TeamName: u.TeamName,
 		IsActive: u.IsActive,
+		Version:  u.Version,
 	}
 }
 
@@ -20,6 +22,7 @@ func MapUserDbToUser(u *pg_model.UserDb) *model.User {
 		Username: u.Username,
 		TeamName: u.TeamName,
 		IsActive: u.IsActive,
+		Version:  u.Version,
 	}
 }
 
@@ -36,29 +39,196 @@ func MapTeamDbToTeam(t *pg_model.TeamDb, members []*model.User) *model.Team {
 	}
 }
 
+func MapTeamSettingsToDb(s *model.TeamSettings) *pg_model.TeamSettingsDb {
+	return &pg_model.TeamSettingsDb{
+		TeamName:          s.TeamName,
+		ReviewersPerPR:    s.ReviewersPerPR,
+		SelectionStrategy: sql.NullString{String: string(s.Strategy), Valid: s.Strategy != ""},
+		AllowCrossTeam:    s.AllowCrossTeam,
+		FallbackTeams:     s.FallbackTeams,
+		MinApprovals:      s.MinApprovals,
+	}
+}
+
+func MapTeamSettingsDbToTeamSettings(s *pg_model.TeamSettingsDb) *model.TeamSettings {
+	return &model.TeamSettings{
+		TeamName:       s.TeamName,
+		ReviewersPerPR: s.ReviewersPerPR,
+		Strategy:       model.SelectionStrategy(s.SelectionStrategy.String),
+		AllowCrossTeam: s.AllowCrossTeam,
+		FallbackTeams:  s.FallbackTeams,
+		MinApprovals:   s.MinApprovals,
+	}
+}
+
 func MapPrToPrDb(pr *model.PullRequest) *pg_model.PullRequestDb {
-	return &pg_model.PullRequestDb{
+	prDb := &pg_model.PullRequestDb{
 		ID:        pr.ID,
 		Name:      pr.Name,
 		AuthorID:  pr.AuthorID,
 		Status:    string(pr.Status),
 		CreatedAt: pr.CreatedAt,
 		MergedAt:  pr.MergedAt,
+		Version:   pr.Version,
+	}
+	if pr.MergeOverrideReason != nil {
+		prDb.MergeOverrideReason = sql.NullString{String: *pr.MergeOverrideReason, Valid: true}
+	}
+	return prDb
+}
+
+func MapPrReviewersToDb(pr *model.PullRequest) []*pg_model.PrReviewerDb {
+	reviewers := make([]*pg_model.PrReviewerDb, 0, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		reviewer := &pg_model.PrReviewerDb{UserID: id}
+		if team, ok := pr.FallbackReviewers[id]; ok {
+			reviewer.FallbackTeam = sql.NullString{String: team, Valid: true}
+		}
+		if review, ok := pr.Reviews[id]; ok {
+			decidedAt := review.DecidedAt
+			reviewer.Decision = sql.NullString{String: string(review.Decision), Valid: true}
+			reviewer.DecidedAt = &decidedAt
+		}
+		reviewers = append(reviewers, reviewer)
 	}
+	return reviewers
 }
 
-func MapPrDbToPr(prDb *pg_model.PullRequestDb, reviewers []*pg_model.UserDb) *model.PullRequest {
+func MapPrDbToPr(prDb *pg_model.PullRequestDb, reviewers []*pg_model.PrReviewerDb) *model.PullRequest {
 	reviewerIDs := make([]string, len(reviewers))
+	fallbacks := make(map[string]string)
+	reviews := make(map[string]*model.Review)
 	for i, r := range reviewers {
-		reviewerIDs[i] = r.ID
+		reviewerIDs[i] = r.UserID
+		if r.FallbackTeam.Valid {
+			fallbacks[r.UserID] = r.FallbackTeam.String
+		}
+		if r.Decision.Valid && r.DecidedAt != nil {
+			reviews[r.UserID] = &model.Review{Decision: model.ReviewDecision(r.Decision.String), DecidedAt: *r.DecidedAt}
+		}
 	}
+
+	var overrideReason *string
+	if prDb.MergeOverrideReason.Valid {
+		overrideReason = &prDb.MergeOverrideReason.String
+	}
+
 	return &model.PullRequest{
-		ID:                prDb.ID,
-		Name:              prDb.Name,
-		AuthorID:          prDb.AuthorID,
-		Status:            model.Status(prDb.Status),
-		CreatedAt:         prDb.CreatedAt,
-		MergedAt:          prDb.MergedAt,
-		AssignedReviewers: reviewerIDs,
+		ID:                  prDb.ID,
+		Name:                prDb.Name,
+		AuthorID:            prDb.AuthorID,
+		Status:              model.Status(prDb.Status),
+		CreatedAt:           prDb.CreatedAt,
+		MergedAt:            prDb.MergedAt,
+		AssignedReviewers:   reviewerIDs,
+		FallbackReviewers:   fallbacks,
+		Reviews:             reviews,
+		MergeOverrideReason: overrideReason,
+		Version:             prDb.Version,
+	}
+}
+
+func MapPrEventToDb(e *model.PrEvent) *pg_model.PrEventDb {
+	return &pg_model.PrEventDb{
+		ID:            e.ID,
+		PrID:          e.PullRequestID,
+		Type:          string(e.Type),
+		ReviewerID:    nullString(e.ReviewerID),
+		OldReviewerID: nullString(e.OldReviewerID),
+		FromStatus:    nullString(string(e.FromStatus)),
+		ToStatus:      nullString(string(e.ToStatus)),
+		Details:       nullString(e.Details),
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+func MapPrEventDbToPrEvent(e *pg_model.PrEventDb) *model.PrEvent {
+	return &model.PrEvent{
+		ID:            e.ID,
+		PullRequestID: e.PrID,
+		Type:          model.PrEventType(e.Type),
+		ReviewerID:    e.ReviewerID.String,
+		OldReviewerID: e.OldReviewerID.String,
+		FromStatus:    model.Status(e.FromStatus.String),
+		ToStatus:      model.Status(e.ToStatus.String),
+		Details:       e.Details.String,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func MapWebhookSubscriptionToDb(s *model.WebhookSubscription) *pg_model.WebhookSubscriptionDb {
+	return &pg_model.WebhookSubscriptionDb{
+		ID:         s.ID,
+		URL:        s.URL,
+		Secret:     s.Secret,
+		EventTypes: s.EventTypes,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+func MapWebhookSubscriptionDbToSubscription(s *pg_model.WebhookSubscriptionDb) *model.WebhookSubscription {
+	return &model.WebhookSubscription{
+		ID:         s.ID,
+		URL:        s.URL,
+		Secret:     s.Secret,
+		EventTypes: s.EventTypes,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+func MapWebhookDeliveryDbToDelivery(d *pg_model.WebhookDeliveryDb) *model.WebhookDelivery {
+	return &model.WebhookDelivery{
+		ID:             d.ID,
+		EventID:        d.OutboxID,
+		EventType:      d.EventType,
+		SubscriptionID: d.SubscriptionID,
+		URL:            d.URL,
+		Secret:         d.Secret,
+		Payload:        d.Payload,
+		Status:         model.DeliveryStatus(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastError:      d.LastError.String,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
+
+func MapIdempotencyKeyDbToRecord(k *pg_model.IdempotencyKeyDb) *model.IdempotencyRecord {
+	return &model.IdempotencyRecord{
+		Key:         k.Key,
+		RequestHash: k.RequestHash,
+		StatusCode:  int(k.StatusCode.Int64),
+		ContentType: k.ContentType,
+		ETag:        k.ETag,
+		Body:        k.ResponseBody,
+		CreatedAt:   k.CreatedAt,
+		ExpiresAt:   k.ExpiresAt,
+		LockedUntil: k.LockedUntil,
+	}
+}
+
+func MapAPITokenToDb(t *model.APIToken) *pg_model.APITokenDb {
+	return &pg_model.APITokenDb{
+		ID:        t.ID,
+		Name:      t.Name,
+		Role:      string(t.Role),
+		UserID:    nullString(t.UserID),
+		TokenHash: t.Hash,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+func MapAPITokenDbToToken(t *pg_model.APITokenDb) *model.APIToken {
+	return &model.APIToken{
+		ID:        t.ID,
+		Name:      t.Name,
+		Role:      model.Role(t.Role),
+		UserID:    t.UserID.String,
+		Hash:      t.TokenHash,
+		CreatedAt: t.CreatedAt,
 	}
 }
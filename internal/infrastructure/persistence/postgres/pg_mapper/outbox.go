@@ -0,0 +1,32 @@
+package pg_mapper
+
+import (
+	"test/internal/domain/model"
+	"test/internal/infrastructure/persistence/postgres/pg_model"
+)
+
+func MapPrEventToOutboxDb(pr *model.PullRequest, e *model.PrEvent) (*pg_model.OutboxDb, error) {
+	msg, err := model.NewPrOutboxMessage(pr, e)
+	if err != nil {
+		return nil, err
+	}
+	return mapOutboxMessageToDb(msg), nil
+}
+
+func MapUserEventToOutboxDb(u *model.User, e *model.UserEvent) (*pg_model.OutboxDb, error) {
+	msg, err := model.NewUserOutboxMessage(u, e)
+	if err != nil {
+		return nil, err
+	}
+	return mapOutboxMessageToDb(msg), nil
+}
+
+func mapOutboxMessageToDb(msg *model.OutboxMessage) *pg_model.OutboxDb {
+	return &pg_model.OutboxDb{
+		AggregateType: msg.AggregateType,
+		AggregateID:   msg.AggregateID,
+		EventType:     msg.EventType,
+		Payload:       msg.Payload,
+		CreatedAt:     msg.CreatedAt,
+	}
+}
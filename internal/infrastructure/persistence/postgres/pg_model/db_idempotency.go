@@ -0,0 +1,18 @@
+package pg_model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type IdempotencyKeyDb struct {
+	Key          string
+	RequestHash  string
+	StatusCode   sql.NullInt64
+	ContentType  string
+	ETag         string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+	LockedUntil  time.Time
+}
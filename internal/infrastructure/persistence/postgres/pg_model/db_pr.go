@@ -1,12 +1,17 @@
 package pg_model
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 type PullRequestDb struct {
-	ID        string
-	Name      string
-	AuthorID  string
-	Status    string
-	CreatedAt time.Time
-	MergedAt  *time.Time
+	ID                  string
+	Name                string
+	AuthorID            string
+	Status              string
+	CreatedAt           time.Time
+	MergedAt            *time.Time
+	MergeOverrideReason sql.NullString
+	Version             int64
 }
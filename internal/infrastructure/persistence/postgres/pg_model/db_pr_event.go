@@ -0,0 +1,18 @@
+package pg_model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type PrEventDb struct {
+	ID            int64
+	PrID          string
+	Type          string
+	ReviewerID    sql.NullString
+	OldReviewerID sql.NullString
+	FromStatus    sql.NullString
+	ToStatus      sql.NullString
+	Details       sql.NullString
+	CreatedAt     time.Time
+}
@@ -0,0 +1,13 @@
+package pg_model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type PrReviewerDb struct {
+	UserID       string
+	FallbackTeam sql.NullString
+	Decision     sql.NullString
+	DecidedAt    *time.Time
+}
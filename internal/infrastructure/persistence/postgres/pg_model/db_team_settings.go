@@ -0,0 +1,12 @@
+package pg_model
+
+import "database/sql"
+
+type TeamSettingsDb struct {
+	TeamName          string
+	ReviewersPerPR    int
+	SelectionStrategy sql.NullString
+	AllowCrossTeam    bool
+	FallbackTeams     []string
+	MinApprovals      int
+}
@@ -0,0 +1,15 @@
+package pg_model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type APITokenDb struct {
+	ID        string
+	Name      string
+	Role      string
+	UserID    sql.NullString
+	TokenHash string
+	CreatedAt time.Time
+}
@@ -5,4 +5,5 @@ type UserDb struct {
 	Username string
 	TeamName string
 	IsActive bool
+	Version  int64
 }
@@ -0,0 +1,38 @@
+package pg_model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type OutboxDb struct {
+	ID            int64
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	Payload       []byte
+	CreatedAt     time.Time
+}
+
+type WebhookSubscriptionDb struct {
+	ID         string
+	URL        string
+	Secret     string
+	EventTypes []string
+	CreatedAt  time.Time
+}
+
+type WebhookDeliveryDb struct {
+	ID             int64
+	OutboxID       int64
+	EventType      string
+	SubscriptionID string
+	URL            string
+	Secret         string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastError      sql.NullString
+	DeliveredAt    *time.Time
+}
@@ -0,0 +1,104 @@
+package pg_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"test/internal/domain/model"
+	"test/internal/infrastructure/persistence/postgres/pg_mapper"
+	"test/internal/infrastructure/persistence/postgres/pg_model"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type IdempotencyRepository struct {
+	db *sql.DB
+	sb sq.StatementBuilderType
+}
+
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		db: db,
+		sb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, rec *model.IdempotencyRecord) (*model.IdempotencyRecord, error) {
+	// An expired row, or the row of a request in progress whose lease has run
+	// out, is taken over as if it did not exist.
+	query, args, err := r.sb.Insert("idempotency_keys").
+		Columns("key", "request_hash", "created_at", "expires_at", "locked_until").
+		Values(rec.Key, rec.RequestHash, rec.CreatedAt, rec.ExpiresAt, rec.LockedUntil).
+		Suffix(`ON CONFLICT (key) DO UPDATE SET request_hash = EXCLUDED.request_hash, status_code = NULL, content_type = '', etag = '', response_body = NULL, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at, locked_until = EXCLUDED.locked_until
+			WHERE idempotency_keys.expires_at <= now() OR (idempotency_keys.status_code IS NULL AND idempotency_keys.locked_until <= now()) RETURNING key`).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var key string
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&key)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	query, args, err = r.sb.Select("key", "request_hash", "status_code", "content_type", "etag", "response_body", "created_at", "expires_at", "locked_until").
+		From("idempotency_keys").
+		Where(sq.Eq{"key": rec.Key}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var k pg_model.IdempotencyKeyDb
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&k.Key, &k.RequestHash, &k.StatusCode, &k.ContentType, &k.ETag, &k.ResponseBody, &k.CreatedAt, &k.ExpiresAt, &k.LockedUntil); err != nil {
+		return nil, err
+	}
+	return pg_mapper.MapIdempotencyKeyDbToRecord(&k), nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, rec *model.IdempotencyRecord) error {
+	query, args, err := r.sb.Update("idempotency_keys").
+		Set("status_code", rec.StatusCode).
+		Set("content_type", rec.ContentType).
+		Set("etag", rec.ETag).
+		Set("response_body", rec.Body).
+		Where(sq.Eq{"key": rec.Key, "request_hash": rec.RequestHash}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	query, args, err := r.sb.Delete("idempotency_keys").
+		Where(sq.Eq{"key": key, "status_code": nil}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	query, args, err := r.sb.Delete("idempotency_keys").
+		Where(sq.Expr("expires_at <= now()")).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
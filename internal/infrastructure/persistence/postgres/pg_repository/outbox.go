@@ -0,0 +1,197 @@
+package pg_repository
+
+import (
+	"context"
+	"database/sql"
+	"test/internal/domain/model"
+	"test/internal/infrastructure/persistence/postgres/pg_mapper"
+	"test/internal/infrastructure/persistence/postgres/pg_model"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// claimDeliveriesQuery leases due deliveries by pushing next_attempt_at forward,
+// so concurrent dispatchers skip them while the HTTP call is in flight.
+const claimDeliveriesQuery = `
+UPDATE webhook_deliveries AS d
+SET next_attempt_at = now() + make_interval(secs => $2)
+FROM outbox AS o, webhook_subscriptions AS s
+WHERE d.id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'PENDING' AND next_attempt_at <= now()
+    ORDER BY next_attempt_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+  AND o.id = d.outbox_id
+  AND s.id = d.subscription_id
+RETURNING d.id, d.outbox_id, o.event_type, d.subscription_id, s.url, s.secret, o.payload,
+          d.status, d.attempts, d.next_attempt_at, d.last_error, d.delivered_at`
+
+type OutboxRepository struct {
+	db *sql.DB
+	sb sq.StatementBuilderType
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+		sb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+func (r *OutboxRepository) FanOut(ctx context.Context, limit int) (n int, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query, args, err := r.sb.Select("id").
+		From("outbox").
+		Where(sq.Eq{"dispatched_at": nil}).
+		OrderBy("id").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	matching := sq.Select("o.id", "s.id").
+		From("outbox AS o").
+		Join("webhook_subscriptions AS s ON cardinality(s.event_types) = 0 OR o.event_type = ANY(s.event_types)").
+		Where(sq.Eq{"o.id": ids})
+
+	query, args, err = r.sb.Insert("webhook_deliveries").
+		Columns("outbox_id", "subscription_id").
+		Select(matching).
+		Suffix("ON CONFLICT (outbox_id, subscription_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return 0, err
+	}
+
+	query, args, err = r.sb.Update("outbox").
+		Set("dispatched_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": ids}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return 0, err
+	}
+
+	return len(ids), nil
+}
+
+func (r *OutboxRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, claimDeliveriesQuery, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*model.WebhookDelivery
+	for rows.Next() {
+		var d pg_model.WebhookDeliveryDb
+		if err := rows.Scan(&d.ID, &d.OutboxID, &d.EventType, &d.SubscriptionID, &d.URL, &d.Secret, &d.Payload,
+			&d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastError, &d.DeliveredAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, pg_mapper.MapWebhookDeliveryDbToDelivery(&d))
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id int64) error {
+	query, args, err := r.sb.Update("webhook_deliveries").
+		Set("status", string(model.DeliveryDelivered)).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("delivered_at", sq.Expr("now()")).
+		Set("last_error", nil).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt *time.Time) error {
+	upd := r.sb.Update("webhook_deliveries").
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("last_error", lastError).
+		Where(sq.Eq{"id": id})
+	if nextAttemptAt == nil {
+		upd = upd.Set("status", string(model.DeliveryDead))
+	} else {
+		upd = upd.Set("next_attempt_at", *nextAttemptAt)
+	}
+
+	query, args, err := upd.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// insertOutboxTx writes outbox rows within the transaction of the change they describe.
+func insertOutboxTx(ctx context.Context, tx *sql.Tx, sb sq.StatementBuilderType, rows []*pg_model.OutboxDb) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	ins := sb.Insert("outbox").Columns("aggregate_type", "aggregate_id", "event_type", "payload", "created_at")
+	for _, row := range rows {
+		ins = ins.Values(row.AggregateType, row.AggregateID, row.EventType, row.Payload, row.CreatedAt)
+	}
+
+	query, args, err := ins.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	return err
+}
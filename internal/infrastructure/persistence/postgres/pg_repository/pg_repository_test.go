@@ -0,0 +1,84 @@
+package pg_repository_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"test/internal/infrastructure/persistence/postgres/migrations"
+	"test/internal/infrastructure/persistence/postgres/pg_migrate"
+	"test/internal/infrastructure/persistence/postgres/pg_repository"
+	"test/internal/infrastructure/persistence/repotest"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// TestConformance runs against the database of TEST_DATABASE_URL, a
+// postgres:// URL. Every test gets its own schema, dropped when it ends.
+func TestConformance(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		db := openSchema(t, dsn)
+		userRepo := pg_repository.NewUserRepository(db)
+		return repotest.Repositories{
+			User:        userRepo,
+			Team:        pg_repository.NewTeamRepository(db, userRepo),
+			Pr:          pg_repository.NewPrRepository(db),
+			Tx:          pg_repository.NewTxManager(db),
+			Idempotency: pg_repository.NewIdempotencyRepository(db),
+		}
+	})
+}
+
+// openSchema creates an empty schema, migrates it and returns a connection pool
+// whose search_path points at it.
+func openSchema(t *testing.T, dsn string) *sql.DB {
+	t.Helper()
+	ctx := context.Background()
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := fmt.Sprintf("conformance_%d", time.Now().UnixNano())
+	if _, err := admin.ExecContext(ctx, "CREATE SCHEMA "+schema); err != nil {
+		admin.Close()
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("drop schema %s: %v", schema, err)
+		}
+		admin.Close()
+	})
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatalf("TEST_DATABASE_URL: %v", err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+
+	db, err := sql.Open("postgres", u.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	m, err := pg_migrate.New(db, migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"test/internal/domain/domain_errors"
 	"test/internal/domain/model"
 	"test/internal/infrastructure/persistence/postgres/pg_mapper"
 	"test/internal/infrastructure/persistence/postgres/pg_model"
@@ -24,64 +25,235 @@ func NewPrRepository(db *sql.DB) *PrRepository {
 }
 
 func (r *PrRepository) GetByID(ctx context.Context, id string) (*model.PullRequest, error) {
-	query, args, err := r.sb.Select("id", "name", "author_id", "status", "created_at", "merged_at").
+	return r.getByID(ctx, id, false)
+}
+
+// GetByIDForUpdate loads a pull request and locks its row until the end of the
+// transaction carried by ctx.
+func (r *PrRepository) GetByIDForUpdate(ctx context.Context, id string) (*model.PullRequest, error) {
+	return r.getByID(ctx, id, true)
+}
+
+func (r *PrRepository) getByID(ctx context.Context, id string, forUpdate bool) (*model.PullRequest, error) {
+	q := r.sb.Select("id", "name", "author_id", "status", "created_at", "merged_at", "merge_override_reason", "version").
 		From("pull_requests").
-		Where(sq.Eq{"id": id}).ToSql()
+		Where(sq.Eq{"id": id})
+	if forUpdate {
+		q = q.Suffix("FOR UPDATE")
+	}
 
+	query, args, err := q.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	row := r.db.QueryRowContext(ctx, query, args...)
+	row := conn(ctx, r.db).QueryRowContext(ctx, query, args...)
 	var dbPR pg_model.PullRequestDb
-	if err := row.Scan(&dbPR.ID, &dbPR.Name, &dbPR.AuthorID, &dbPR.Status, &dbPR.CreatedAt, &dbPR.MergedAt); err != nil {
+	if err := row.Scan(&dbPR.ID, &dbPR.Name, &dbPR.AuthorID, &dbPR.Status, &dbPR.CreatedAt, &dbPR.MergedAt, &dbPR.MergeOverrideReason, &dbPR.Version); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
 
-	reviewerQuery, reviewerArgs, err := r.sb.
-		Select("user_id").
+	reviewers, err := r.getReviewers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return pg_mapper.MapPrDbToPr(&dbPR, reviewers), nil
+}
+
+func (r *PrRepository) getReviewers(ctx context.Context, prID string) ([]*pg_model.PrReviewerDb, error) {
+	query, args, err := r.sb.
+		Select("user_id", "fallback_team", "decision", "decided_at").
 		From("pr_reviewers").
-		Where(sq.Eq{"pr_id": id}).
+		Where(sq.Eq{"pr_id": prID}).
 		ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := r.db.QueryContext(ctx, reviewerQuery, reviewerArgs...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var reviewers []*pg_model.UserDb
+	var reviewers []*pg_model.PrReviewerDb
 	for rows.Next() {
-		var uid string
-		if err := rows.Scan(&uid); err != nil {
+		var reviewer pg_model.PrReviewerDb
+		if err := rows.Scan(&reviewer.UserID, &reviewer.FallbackTeam, &reviewer.Decision, &reviewer.DecidedAt); err != nil {
 			return nil, err
 		}
-		reviewers = append(reviewers, &pg_model.UserDb{ID: uid})
+		reviewers = append(reviewers, &reviewer)
 	}
+	return reviewers, rows.Err()
+}
 
-	return pg_mapper.MapPrDbToPr(&dbPR, reviewers), nil
+// Create inserts a new pull request with its reviewers and events. It returns
+// ErrPullRequestExists instead of overwriting a pull request with the same id.
+func (r *PrRepository) Create(ctx context.Context, pr *model.PullRequest) error {
+	dbPR := pg_mapper.MapPrToPrDb(pr)
+
+	query, args, err := r.sb.Insert("pull_requests").
+		Columns("id", "name", "author_id", "status", "created_at", "merged_at", "merge_override_reason", "version").
+		Values(dbPR.ID, dbPR.Name, dbPR.AuthorID, dbPR.Status, dbPR.CreatedAt, dbPR.MergedAt, dbPR.MergeOverrideReason, dbPR.Version).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = inTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			if isUniqueViolation(err) {
+				return domain_errors.ErrPullRequestExists
+			}
+			return err
+		}
+		return r.saveChildrenTx(ctx, tx, pr)
+	})
+	if err != nil {
+		return err
+	}
+
+	pr.ClearPendingEvents()
+	return nil
 }
 
+// Save updates an existing pull request together with its reviewers and events
+// and increments its version. It returns ErrVersionMismatch if the stored version
+// differs from pr.Version.
 func (r *PrRepository) Save(ctx context.Context, pr *model.PullRequest) error {
 	dbPR := pg_mapper.MapPrToPrDb(pr)
 
-	query, args, err := r.sb.Insert("pull_requests").
-		Columns("id", "name", "author_id", "status", "created_at", "merged_at").
-		Values(dbPR.ID, dbPR.Name, dbPR.AuthorID, dbPR.Status, dbPR.CreatedAt, dbPR.MergedAt).
-		Suffix("ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, author_id = EXCLUDED.author_id, status = EXCLUDED.status, created_at = EXCLUDED.created_at, merged_at = EXCLUDED.merged_at").
+	query, args, err := r.sb.Update("pull_requests").
+		Set("name", dbPR.Name).
+		Set("author_id", dbPR.AuthorID).
+		Set("status", dbPR.Status).
+		Set("merged_at", dbPR.MergedAt).
+		Set("merge_override_reason", dbPR.MergeOverrideReason).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"id": dbPR.ID, "version": dbPR.Version}).
 		ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = inTx(ctx, r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return r.missingOrStale(ctx, tx, pr.ID)
+		}
+		return r.saveChildrenTx(ctx, tx, pr)
+	})
+	if err != nil {
+		return err
+	}
+
+	pr.Version++
+	pr.ClearPendingEvents()
+	return nil
+}
+
+// missingOrStale explains why an update of the pull request matched no rows.
+func (r *PrRepository) missingOrStale(ctx context.Context, tx *sql.Tx, id string) error {
+	query, args, err := r.sb.Select("1").From("pull_requests").Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	var tmp int
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&tmp); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain_errors.ErrPullRequestNotFound
+		}
+		return err
+	}
+	return domain_errors.ErrVersionMismatch
+}
+
+// saveChildrenTx writes the reviewer set, pending events and their outbox rows of pr.
+func (r *PrRepository) saveChildrenTx(ctx context.Context, tx *sql.Tx, pr *model.PullRequest) error {
+	if err := r.saveReviewersTx(ctx, tx, pr); err != nil {
+		return err
+	}
+	if err := r.saveEventsTx(ctx, tx, pr.PendingEvents()); err != nil {
+		return err
+	}
+	return r.saveOutboxTx(ctx, tx, pr)
+}
+
+// saveOutboxTx queues pending events of pr for webhook delivery.
+func (r *PrRepository) saveOutboxTx(ctx context.Context, tx *sql.Tx, pr *model.PullRequest) error {
+	rows := make([]*pg_model.OutboxDb, 0, len(pr.PendingEvents()))
+	for _, e := range pr.PendingEvents() {
+		row, err := pg_mapper.MapPrEventToOutboxDb(pr, e)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+	return insertOutboxTx(ctx, tx, r.sb, rows)
+}
 
+func (r *PrRepository) saveEventsTx(ctx context.Context, tx *sql.Tx, events []*model.PrEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ins := r.sb.Insert("pr_events").
+		Columns("pr_id", "type", "reviewer_id", "old_reviewer_id", "from_status", "to_status", "details", "created_at")
+	for _, e := range events {
+		dbEvent := pg_mapper.MapPrEventToDb(e)
+		ins = ins.Values(dbEvent.PrID, dbEvent.Type, dbEvent.ReviewerID, dbEvent.OldReviewerID, dbEvent.FromStatus, dbEvent.ToStatus, dbEvent.Details, dbEvent.CreatedAt)
+	}
+
+	query, args, err := ins.ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = r.db.ExecContext(ctx, query, args...)
+	_, err = tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+// saveReviewersTx syncs pr_reviewers with pr.AssignedReviewers: rows of removed
+// reviewers are deleted, the rest are upserted with their fallback team and decision.
+func (r *PrRepository) saveReviewersTx(ctx context.Context, tx *sql.Tx, pr *model.PullRequest) error {
+	del := r.sb.Delete("pr_reviewers").Where(sq.Eq{"pr_id": pr.ID})
+	if len(pr.AssignedReviewers) > 0 {
+		del = del.Where(sq.NotEq{"user_id": pr.AssignedReviewers})
+	}
+
+	query, args, err := del.ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if len(pr.AssignedReviewers) == 0 {
+		return nil
+	}
+
+	ins := r.sb.Insert("pr_reviewers").Columns("pr_id", "user_id", "fallback_team", "decision", "decided_at")
+	for _, reviewer := range pg_mapper.MapPrReviewersToDb(pr) {
+		ins = ins.Values(pr.ID, reviewer.UserID, reviewer.FallbackTeam, reviewer.Decision, reviewer.DecidedAt)
+	}
+
+	query, args, err = ins.Suffix("ON CONFLICT (pr_id, user_id) DO UPDATE SET fallback_team = EXCLUDED.fallback_team, decision = EXCLUDED.decision, decided_at = EXCLUDED.decided_at").ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
 	return err
 }
 
@@ -92,14 +264,14 @@ func (r *PrRepository) CheckUserOpenPRs(ctx context.Context, userIDs []string) (
 
 	authorQuery, authorArgs, err := r.sb.Select("1").
 		From("pull_requests").
-		Where(sq.Eq{"author_id": userIDs, "status": "OPEN"}).
+		Where(sq.Eq{"author_id": userIDs, "status": []string{"OPEN", "DRAFT"}}).
 		Limit(1).ToSql()
 
 	if err != nil {
 		return false, err
 	}
 
-	row := r.db.QueryRowContext(ctx, authorQuery, authorArgs...)
+	row := conn(ctx, r.db).QueryRowContext(ctx, authorQuery, authorArgs...)
 	var tmp int
 	if err := row.Scan(&tmp); err == nil {
 		return true, nil
@@ -119,7 +291,7 @@ func (r *PrRepository) CheckUserOpenPRs(ctx context.Context, userIDs []string) (
 		return false, err
 	}
 
-	row = r.db.QueryRowContext(ctx, reviewerQuery, reviewerArgs...)
+	row = conn(ctx, r.db).QueryRowContext(ctx, reviewerQuery, reviewerArgs...)
 	if err := row.Scan(&tmp); err == nil {
 		return true, nil
 	} else if errors.Is(err, sql.ErrNoRows) {
@@ -131,7 +303,7 @@ func (r *PrRepository) CheckUserOpenPRs(ctx context.Context, userIDs []string) (
 
 func (r *PrRepository) GetByReviewer(ctx context.Context, reviewerID string) ([]*model.PullRequest, error) {
 	query, args, err := r.sb.
-		Select("pr.id", "pr.name", "pr.author_id", "pr.status", "pr.created_at", "pr.merged_at").
+		Select("pr.id", "pr.name", "pr.author_id", "pr.status", "pr.created_at", "pr.merged_at", "pr.merge_override_reason", "pr.version").
 		From("pr_reviewers AS prr").
 		Join("pull_requests AS pr ON pr.id = prr.pr_id").
 		Where(sq.Eq{"prr.user_id": reviewerID}).
@@ -142,7 +314,7 @@ func (r *PrRepository) GetByReviewer(ctx context.Context, reviewerID string) ([]
 		return nil, err
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -152,37 +324,87 @@ func (r *PrRepository) GetByReviewer(ctx context.Context, reviewerID string) ([]
 
 	for rows.Next() {
 		var dbPR pg_model.PullRequestDb
-		if err := rows.Scan(&dbPR.ID, &dbPR.Name, &dbPR.AuthorID, &dbPR.Status, &dbPR.CreatedAt, &dbPR.MergedAt); err != nil {
+		if err := rows.Scan(&dbPR.ID, &dbPR.Name, &dbPR.AuthorID, &dbPR.Status, &dbPR.CreatedAt, &dbPR.MergedAt, &dbPR.MergeOverrideReason, &dbPR.Version); err != nil {
 			return nil, err
 		}
 
-		reviewerQuery, reviewerArgs, err := r.sb.
-			Select("user_id").
-			From("pr_reviewers").
-			Where(sq.Eq{"pr_id": dbPR.ID}).
-			ToSql()
+		reviewers, err := r.getReviewers(ctx, dbPR.ID)
 		if err != nil {
 			return nil, err
 		}
 
-		reviewerRows, err := r.db.QueryContext(ctx, reviewerQuery, reviewerArgs...)
-		if err != nil {
+		result = append(result, pg_mapper.MapPrDbToPr(&dbPR, reviewers))
+	}
+
+	return result, nil
+}
+
+func (r *PrRepository) CountOpenReviewsByTeam(ctx context.Context, team string) (map[string]int, error) {
+	return r.countOpenReviews(ctx, sq.Eq{"u.team_name": team})
+}
+
+func (r *PrRepository) CountOpenReviews(ctx context.Context) (map[string]int, error) {
+	return r.countOpenReviews(ctx, nil)
+}
+
+func (r *PrRepository) countOpenReviews(ctx context.Context, where sq.Sqlizer) (map[string]int, error) {
+	q := r.sb.
+		Select("u.id", "COUNT(pr.id)").
+		From("users AS u").
+		LeftJoin("pr_reviewers AS prr ON prr.user_id = u.id").
+		LeftJoin("pull_requests AS pr ON pr.id = prr.pr_id AND pr.status = 'OPEN'").
+		GroupBy("u.id")
+	if where != nil {
+		q = q.Where(where)
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var uid string
+		var count int
+		if err := rows.Scan(&uid, &count); err != nil {
 			return nil, err
 		}
+		counts[uid] = count
+	}
+	return counts, rows.Err()
+}
 
-		var reviewers []*pg_model.UserDb
-		for reviewerRows.Next() {
-			var uid string
-			if err := reviewerRows.Scan(&uid); err != nil {
-				reviewerRows.Close()
-				return nil, err
-			}
-			reviewers = append(reviewers, &pg_model.UserDb{ID: uid})
-		}
-		reviewerRows.Close()
+func (r *PrRepository) GetHistory(ctx context.Context, prID string) ([]*model.PrEvent, error) {
+	query, args, err := r.sb.
+		Select("id", "pr_id", "type", "reviewer_id", "old_reviewer_id", "from_status", "to_status", "details", "created_at").
+		From("pr_events").
+		Where(sq.Eq{"pr_id": prID}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
 
-		result = append(result, pg_mapper.MapPrDbToPr(&dbPR, reviewers))
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return result, nil
+	events := []*model.PrEvent{}
+	for rows.Next() {
+		var e pg_model.PrEventDb
+		if err := rows.Scan(&e.ID, &e.PrID, &e.Type, &e.ReviewerID, &e.OldReviewerID, &e.FromStatus, &e.ToStatus, &e.Details, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, pg_mapper.MapPrEventDbToPrEvent(&e))
+	}
+	return events, rows.Err()
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"test/internal/domain/domain_errors"
 	"test/internal/domain/model"
 	"test/internal/infrastructure/persistence/postgres/pg_mapper"
 	"test/internal/infrastructure/persistence/postgres/pg_model"
@@ -25,20 +26,9 @@ func NewTeamRepository(db *sql.DB, userRepo *UserRepository) *TeamRepository {
 	}
 }
 
+// Create inserts the team and its members. It returns ErrTeamExists when a team
+// with the same name was created concurrently.
 func (r *TeamRepository) Create(ctx context.Context, team *model.Team) error {
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		} else {
-			tx.Commit()
-		}
-	}()
-
 	teamDb := pg_mapper.MapTeamToTeamDb(team)
 
 	query, args, err := r.sb.Insert("teams").
@@ -49,18 +39,22 @@ func (r *TeamRepository) Create(ctx context.Context, team *model.Team) error {
 		return err
 	}
 
-	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
-		return err
-	}
-
-	for _, u := range team.Members {
-		u.TeamName = team.Name
-		if err = r.userRepo.SaveTx(ctx, tx, u); err != nil {
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			if isUniqueViolation(err) {
+				return domain_errors.ErrTeamExists
+			}
 			return err
 		}
-	}
 
-	return nil
+		for _, u := range team.Members {
+			u.TeamName = team.Name
+			if err := r.userRepo.SaveTx(ctx, tx, u); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func (r *TeamRepository) GetByName(ctx context.Context, name string) (*model.Team, error) {
@@ -71,7 +65,7 @@ func (r *TeamRepository) GetByName(ctx context.Context, name string) (*model.Tea
 	if err != nil {
 		return nil, err
 	}
-	row := r.db.QueryRowContext(ctx, query, args...)
+	row := conn(ctx, r.db).QueryRowContext(ctx, query, args...)
 	var teamDb pg_model.TeamDb
 
 	if err := row.Scan(&teamDb.Name); err != nil {
@@ -90,3 +84,102 @@ func (r *TeamRepository) GetByName(ctx context.Context, name string) (*model.Tea
 
 	return team, nil
 }
+
+func (r *TeamRepository) GetSettings(ctx context.Context, team string) (*model.TeamSettings, error) {
+	query, args, err := r.sb.Select("team_name", "reviewers_per_pr", "selection_strategy", "allow_cross_team", "min_approvals").
+		From("team_settings").
+		Where(sq.Eq{"team_name": team}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	row := conn(ctx, r.db).QueryRowContext(ctx, query, args...)
+	var dbSettings pg_model.TeamSettingsDb
+	if err := row.Scan(&dbSettings.TeamName, &dbSettings.ReviewersPerPR, &dbSettings.SelectionStrategy, &dbSettings.AllowCrossTeam, &dbSettings.MinApprovals); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	fallbackQuery, fallbackArgs, err := r.sb.Select("fallback_team").
+		From("team_fallbacks").
+		Where(sq.Eq{"team_name": team}).
+		OrderBy("position").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fallbackQuery, fallbackArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dbSettings.FallbackTeams = []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		dbSettings.FallbackTeams = append(dbSettings.FallbackTeams, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pg_mapper.MapTeamSettingsDbToTeamSettings(&dbSettings), nil
+}
+
+func (r *TeamRepository) SaveSettings(ctx context.Context, settings *model.TeamSettings) error {
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		return r.saveSettingsTx(ctx, tx, settings)
+	})
+}
+
+func (r *TeamRepository) saveSettingsTx(ctx context.Context, tx *sql.Tx, settings *model.TeamSettings) error {
+	dbSettings := pg_mapper.MapTeamSettingsToDb(settings)
+
+	query, args, err := r.sb.Insert("team_settings").
+		Columns("team_name", "reviewers_per_pr", "selection_strategy", "allow_cross_team", "min_approvals").
+		Values(dbSettings.TeamName, dbSettings.ReviewersPerPR, dbSettings.SelectionStrategy, dbSettings.AllowCrossTeam, dbSettings.MinApprovals).
+		Suffix("ON CONFLICT (team_name) DO UPDATE SET reviewers_per_pr = EXCLUDED.reviewers_per_pr, selection_strategy = EXCLUDED.selection_strategy, allow_cross_team = EXCLUDED.allow_cross_team, min_approvals = EXCLUDED.min_approvals").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	query, args, err = r.sb.Delete("team_fallbacks").
+		Where(sq.Eq{"team_name": dbSettings.TeamName}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if len(dbSettings.FallbackTeams) == 0 {
+		return nil
+	}
+
+	ins := r.sb.Insert("team_fallbacks").Columns("team_name", "fallback_team", "position")
+	for i, name := range dbSettings.FallbackTeams {
+		ins = ins.Values(dbSettings.TeamName, name, i)
+	}
+
+	query, args, err = ins.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	return err
+}
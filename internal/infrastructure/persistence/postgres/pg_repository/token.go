@@ -0,0 +1,106 @@
+package pg_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"test/internal/domain/model"
+	"test/internal/infrastructure/persistence/postgres/pg_mapper"
+	"test/internal/infrastructure/persistence/postgres/pg_model"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type TokenRepository struct {
+	db *sql.DB
+	sb sq.StatementBuilderType
+}
+
+func NewTokenRepository(db *sql.DB) *TokenRepository {
+	return &TokenRepository{
+		db: db,
+		sb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+var tokenColumns = []string{"id", "name", "role", "user_id", "token_hash", "created_at"}
+
+func (r *TokenRepository) Create(ctx context.Context, t *model.APIToken) error {
+	dbToken := pg_mapper.MapAPITokenToDb(t)
+
+	query, args, err := r.sb.Insert("api_tokens").
+		Columns(tokenColumns...).
+		Values(dbToken.ID, dbToken.Name, dbToken.Role, dbToken.UserID, dbToken.TokenHash, dbToken.CreatedAt).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return mapError(err)
+}
+
+func (r *TokenRepository) GetByID(ctx context.Context, id string) (*model.APIToken, error) {
+	return r.getOne(ctx, sq.Eq{"id": id})
+}
+
+func (r *TokenRepository) GetByHash(ctx context.Context, hash string) (*model.APIToken, error) {
+	return r.getOne(ctx, sq.Eq{"token_hash": hash})
+}
+
+func (r *TokenRepository) getOne(ctx context.Context, where sq.Eq) (*model.APIToken, error) {
+	query, args, err := r.sb.Select(tokenColumns...).
+		From("api_tokens").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var t pg_model.APITokenDb
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&t.ID, &t.Name, &t.Role, &t.UserID, &t.TokenHash, &t.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return pg_mapper.MapAPITokenDbToToken(&t), nil
+}
+
+func (r *TokenRepository) List(ctx context.Context) ([]*model.APIToken, error) {
+	query, args, err := r.sb.Select(tokenColumns...).
+		From("api_tokens").
+		OrderBy("created_at").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []*model.APIToken{}
+	for rows.Next() {
+		var t pg_model.APITokenDb
+		if err := rows.Scan(&t.ID, &t.Name, &t.Role, &t.UserID, &t.TokenHash, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, pg_mapper.MapAPITokenDbToToken(&t))
+	}
+	return tokens, rows.Err()
+}
+
+func (r *TokenRepository) Delete(ctx context.Context, id string) error {
+	query, args, err := r.sb.Delete("api_tokens").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"test/internal/domain/domain_errors"
 	"test/internal/domain/model"
 	"test/internal/infrastructure/persistence/postgres/pg_mapper"
 	"test/internal/infrastructure/persistence/postgres/pg_model"
@@ -25,14 +26,14 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*model.User, error) {
 	q := r.sb.
-		Select("id", "username", "team_name", "is_active").
+		Select("id", "username", "team_name", "is_active", "version").
 		From("users").
 		Where(sq.Eq{"id": id})
 
-	row := q.RunWith(r.db).QueryRowContext(ctx)
+	row := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx)
 	var dbUser pg_model.UserDb
 
-	err := row.Scan(&dbUser.ID, &dbUser.Username, &dbUser.TeamName, &dbUser.IsActive)
+	err := row.Scan(&dbUser.ID, &dbUser.Username, &dbUser.TeamName, &dbUser.IsActive, &dbUser.Version)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -42,45 +43,98 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*model.User, e
 	return pg_mapper.MapUserDbToUser(&dbUser), nil
 }
 
+// Save updates an existing user and increments its version. It returns
+// ErrVersionMismatch if the stored version differs from u.Version.
 func (r *UserRepository) Save(ctx context.Context, u *model.User) error {
 	dbUser := pg_mapper.MapUserToUserDb(u)
 
-	query, args, err := r.sb.Insert("users").
-		Columns("id", "username", "team_name", "is_active").
-		Values(dbUser.ID, dbUser.Username, dbUser.TeamName, dbUser.IsActive).
-		Suffix("ON CONFLICT (id) DO UPDATE SET username = EXCLUDED.username, team_name = EXCLUDED.team_name, is_active = EXCLUDED.is_active").
+	query, args, err := r.sb.Update("users").
+		Set("username", dbUser.Username).
+		Set("team_name", dbUser.TeamName).
+		Set("is_active", dbUser.IsActive).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"id": dbUser.ID, "version": dbUser.Version}).
 		ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = r.db.ExecContext(ctx, query, args...)
-	return err
+	err = inTx(ctx, r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return r.missingOrStale(ctx, tx, u.ID)
+		}
+		return r.saveOutboxTx(ctx, tx, u)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.Version++
+	u.ClearPendingEvents()
+	return nil
 }
 
+// SaveTx inserts or overwrites the user within tx regardless of its version.
 func (r *UserRepository) SaveTx(ctx context.Context, tx *sql.Tx, u *model.User) error {
 	dbUser := pg_mapper.MapUserToUserDb(u)
 
 	query, args, err := r.sb.Insert("users").
 		Columns("id", "username", "team_name", "is_active").
 		Values(dbUser.ID, dbUser.Username, dbUser.TeamName, dbUser.IsActive).
-		Suffix("ON CONFLICT (id) DO UPDATE SET username = EXCLUDED.username, team_name = EXCLUDED.team_name, is_active = EXCLUDED.is_active").
+		Suffix("ON CONFLICT (id) DO UPDATE SET username = EXCLUDED.username, team_name = EXCLUDED.team_name, is_active = EXCLUDED.is_active, version = users.version + 1 RETURNING version").
 		ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.ExecContext(ctx, query, args...)
-	return err
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&u.Version); err != nil {
+		return err
+	}
+	return r.saveOutboxTx(ctx, tx, u)
+}
+
+func (r *UserRepository) missingOrStale(ctx context.Context, tx *sql.Tx, id string) error {
+	query, args, err := r.sb.Select("1").From("users").Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	var tmp int
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&tmp); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain_errors.ErrUserNotFound
+		}
+		return err
+	}
+	return domain_errors.ErrVersionMismatch
+}
+
+// saveOutboxTx queues pending events of u for webhook delivery.
+func (r *UserRepository) saveOutboxTx(ctx context.Context, tx *sql.Tx, u *model.User) error {
+	rows := make([]*pg_model.OutboxDb, 0, len(u.PendingEvents()))
+	for _, e := range u.PendingEvents() {
+		row, err := pg_mapper.MapUserEventToOutboxDb(u, e)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+	return insertOutboxTx(ctx, tx, r.sb, rows)
 }
 
 func (r *UserRepository) GetActiveByTeam(ctx context.Context, team string) ([]*model.User, error) {
 	q := r.sb.
-		Select("id", "username", "team_name", "is_active").
+		Select("id", "username", "team_name", "is_active", "version").
 		From("users").
 		Where(sq.Eq{"team_name": team, "is_active": true})
 
-	rows, err := q.RunWith(r.db).QueryContext(ctx)
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +143,7 @@ func (r *UserRepository) GetActiveByTeam(ctx context.Context, team string) ([]*m
 	var users []*model.User
 	for rows.Next() {
 		var dbUser pg_model.UserDb
-		if err := rows.Scan(&dbUser.ID, &dbUser.Username, &dbUser.TeamName, &dbUser.IsActive); err != nil {
+		if err := rows.Scan(&dbUser.ID, &dbUser.Username, &dbUser.TeamName, &dbUser.IsActive, &dbUser.Version); err != nil {
 			return nil, err
 		}
 		users = append(users, pg_mapper.MapUserDbToUser(&dbUser))
@@ -99,11 +153,11 @@ func (r *UserRepository) GetActiveByTeam(ctx context.Context, team string) ([]*m
 
 func (r *UserRepository) GetByTeam(ctx context.Context, team string) ([]*model.User, error) {
 	q := r.sb.
-		Select("id", "username", "team_name", "is_active").
+		Select("id", "username", "team_name", "is_active", "version").
 		From("users").
 		Where(sq.Eq{"team_name": team})
 
-	rows, err := q.RunWith(r.db).QueryContext(ctx)
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +166,7 @@ func (r *UserRepository) GetByTeam(ctx context.Context, team string) ([]*model.U
 	var users []*model.User
 	for rows.Next() {
 		var dbUser pg_model.UserDb
-		if err := rows.Scan(&dbUser.ID, &dbUser.Username, &dbUser.TeamName, &dbUser.IsActive); err != nil {
+		if err := rows.Scan(&dbUser.ID, &dbUser.Username, &dbUser.TeamName, &dbUser.IsActive, &dbUser.Version); err != nil {
 			return nil, err
 		}
 		users = append(users, pg_mapper.MapUserDbToUser(&dbUser))
@@ -0,0 +1,134 @@
+package pg_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"test/internal/domain/model"
+	"test/internal/infrastructure/persistence/postgres/pg_mapper"
+	"test/internal/infrastructure/persistence/postgres/pg_model"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+type WebhookRepository struct {
+	db *sql.DB
+	sb sq.StatementBuilderType
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{
+		db: db,
+		sb: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, s *model.WebhookSubscription) error {
+	dbSub := pg_mapper.MapWebhookSubscriptionToDb(s)
+
+	query, args, err := r.sb.Insert("webhook_subscriptions").
+		Columns("id", "url", "secret", "event_types", "created_at").
+		Values(dbSub.ID, dbSub.URL, dbSub.Secret, pq.Array(dbSub.EventTypes), dbSub.CreatedAt).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, id string) (*model.WebhookSubscription, error) {
+	query, args, err := r.sb.Select("id", "url", "secret", "event_types", "created_at").
+		From("webhook_subscriptions").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRowContext(ctx, query, args...)
+	var dbSub pg_model.WebhookSubscriptionDb
+	if err := row.Scan(&dbSub.ID, &dbSub.URL, &dbSub.Secret, pq.Array(&dbSub.EventTypes), &dbSub.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return pg_mapper.MapWebhookSubscriptionDbToSubscription(&dbSub), nil
+}
+
+func (r *WebhookRepository) List(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	query, args, err := r.sb.Select("id", "url", "secret", "event_types", "created_at").
+		From("webhook_subscriptions").
+		OrderBy("created_at").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subs := []*model.WebhookSubscription{}
+	for rows.Next() {
+		var dbSub pg_model.WebhookSubscriptionDb
+		if err := rows.Scan(&dbSub.ID, &dbSub.URL, &dbSub.Secret, pq.Array(&dbSub.EventTypes), &dbSub.CreatedAt); err != nil {
+			return nil, err
+		}
+		subs = append(subs, pg_mapper.MapWebhookSubscriptionDbToSubscription(&dbSub))
+	}
+	return subs, rows.Err()
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	query, args, err := r.sb.Delete("webhook_subscriptions").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionID string, status model.DeliveryStatus) ([]*model.WebhookDelivery, error) {
+	q := r.sb.Select("d.id", "d.outbox_id", "o.event_type", "d.subscription_id", "s.url", "o.payload",
+		"d.status", "d.attempts", "d.next_attempt_at", "d.last_error", "d.delivered_at").
+		From("webhook_deliveries AS d").
+		Join("outbox AS o ON o.id = d.outbox_id").
+		Join("webhook_subscriptions AS s ON s.id = d.subscription_id").
+		Where(sq.Eq{"d.subscription_id": subscriptionID}).
+		OrderBy("d.id DESC").
+		Limit(100)
+	if status != "" {
+		q = q.Where(sq.Eq{"d.status": string(status)})
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []*model.WebhookDelivery{}
+	for rows.Next() {
+		var d pg_model.WebhookDeliveryDb
+		if err := rows.Scan(&d.ID, &d.OutboxID, &d.EventType, &d.SubscriptionID, &d.URL, &d.Payload,
+			&d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastError, &d.DeliveredAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, pg_mapper.MapWebhookDeliveryDbToDelivery(&d))
+	}
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,402 @@
+// Package repotest is a conformance suite for implementations of the
+// repository interfaces. Every storage backend runs it, so that the service
+// behaves the same whichever backend it is started with.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"test/internal/domain/domain_errors"
+	"test/internal/domain/model"
+	"test/internal/domain/repository"
+	"testing"
+	"time"
+)
+
+// Repositories are the repositories of one backend, sharing one empty store.
+type Repositories struct {
+	User        repository.UserRepository
+	Team        repository.TeamRepository
+	Pr          repository.PrRepository
+	Tx          repository.TxManager
+	Idempotency repository.IdempotencyRepository
+}
+
+// Run runs the suite. newRepos is called once per test and must return
+// repositories backed by a fresh, empty store.
+func Run(t *testing.T, newRepos func(t *testing.T) Repositories) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, r Repositories)
+	}{
+		{"TeamCreateIsUnique", testTeamCreateIsUnique},
+		{"PrCreateIsUnique", testPrCreateIsUnique},
+		{"PrRoundTrip", testPrRoundTrip},
+		{"UserRequiresTeam", testUserRequiresTeam},
+		{"PrRequiresAuthor", testPrRequiresAuthor},
+		{"PrRequiresReviewers", testPrRequiresReviewers},
+		{"PrVersionConflict", testPrVersionConflict},
+		{"UserVersionConflict", testUserVersionConflict},
+		{"SaveMissing", testSaveMissing},
+		{"TxRollback", testTxRollback},
+		{"TxRollbackOnRepositoryError", testTxRollbackOnRepositoryError},
+		{"TxCommit", testTxCommit},
+		{"IdempotencyKeyInProgress", testIdempotencyKeyInProgress},
+		{"IdempotencyAbandonedKeyIsTakenOver", testIdempotencyAbandonedKeyIsTakenOver},
+		{"IdempotencyCompletedKeyOutlivesLease", testIdempotencyCompletedKeyOutlivesLease},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepos(t))
+		})
+	}
+}
+
+var errRollback = errors.New("rollback")
+
+// seedTeam creates team "backend" with the active users u1, u2 and u3.
+func seedTeam(t *testing.T, r Repositories) {
+	t.Helper()
+	team := model.NewTeam("backend", []*model.User{
+		model.NewUser("u1", "alice", "backend", true),
+		model.NewUser("u2", "bob", "backend", true),
+		model.NewUser("u3", "carol", "backend", true),
+	})
+	if err := r.Team.Create(context.Background(), team); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+}
+
+// seedPr creates the open pull request pr-1 by u1 with reviewer u2.
+func seedPr(t *testing.T, r Repositories) *model.PullRequest {
+	t.Helper()
+	pr := model.NewPr("pr-1", "Add fallback teams", "u1")
+	pr.AssignReviewer("u2", "")
+	if err := r.Pr.Create(context.Background(), pr); err != nil {
+		t.Fatalf("create pull request: %v", err)
+	}
+	return pr
+}
+
+func getPr(t *testing.T, r Repositories, id string) *model.PullRequest {
+	t.Helper()
+	pr, err := r.Pr.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("get pull request %s: %v", id, err)
+	}
+	return pr
+}
+
+func testTeamCreateIsUnique(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, r)
+
+	err := r.Team.Create(ctx, model.NewTeam("backend", []*model.User{model.NewUser("u4", "dave", "backend", true)}))
+	if !errors.Is(err, domain_errors.ErrTeamExists) {
+		t.Fatalf("second create error = %v, want ErrTeamExists", err)
+	}
+	u4, err := r.User.GetByID(ctx, "u4")
+	if err != nil || u4 != nil {
+		t.Errorf("member of the rejected team = %+v, %v, want nil", u4, err)
+	}
+}
+
+func testPrCreateIsUnique(t *testing.T, r Repositories) {
+	seedTeam(t, r)
+	seedPr(t, r)
+
+	err := r.Pr.Create(context.Background(), model.NewPr("pr-1", "Overwrite", "u3"))
+	if !errors.Is(err, domain_errors.ErrPullRequestExists) {
+		t.Fatalf("second create error = %v, want ErrPullRequestExists", err)
+	}
+	if pr := getPr(t, r, "pr-1"); pr.Name != "Add fallback teams" || pr.AuthorID != "u1" {
+		t.Errorf("pull request was overwritten: %+v", pr)
+	}
+}
+
+func testPrRoundTrip(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, r)
+	pr := seedPr(t, r)
+
+	if err := pr.SubmitReview("u2", model.DecisionApproved); err != nil {
+		t.Fatal(err)
+	}
+	pr.AssignReviewer("u3", "")
+	if err := r.Pr.Save(ctx, pr); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	got := getPr(t, r, "pr-1")
+	if got.Status != model.StatusOpen || got.Version != 2 || got.Approvals() != 1 || len(got.AssignedReviewers) != 2 {
+		t.Errorf("stored pull request = %+v, want OPEN version 2 with 2 reviewers and 1 approval", got)
+	}
+	if len(got.PendingEvents()) != 0 {
+		t.Errorf("loaded pull request has pending events")
+	}
+
+	byReviewer, err := r.Pr.GetByReviewer(ctx, "u3")
+	if err != nil || len(byReviewer) != 1 || byReviewer[0].ID != "pr-1" {
+		t.Errorf("GetByReviewer(u3) = %v, %v, want [pr-1]", byReviewer, err)
+	}
+
+	counts, err := r.Pr.CountOpenReviewsByTeam(ctx, "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts["u1"] != 0 || counts["u2"] != 1 || counts["u3"] != 1 {
+		t.Errorf("CountOpenReviewsByTeam = %v, want u1:0 u2:1 u3:1", counts)
+	}
+
+	history, err := r.Pr.GetHistory(ctx, "pr-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []model.PrEventType{model.PrEventCreated, model.PrEventReviewerAssigned, model.PrEventReviewSubmitted, model.PrEventReviewerAssigned}
+	if len(history) != len(want) {
+		t.Fatalf("history has %d events, want %d", len(history), len(want))
+	}
+	for i, e := range history {
+		if e.Type != want[i] {
+			t.Errorf("event %d = %s, want %s", i, e.Type, want[i])
+		}
+	}
+}
+
+func testUserRequiresTeam(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, r)
+
+	u, err := r.User.GetByID(ctx, "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.TeamName = "missing"
+	if err := r.User.Save(ctx, u); !errors.Is(err, repository.ErrForeignKeyViolation) {
+		t.Fatalf("save with a missing team error = %v, want ErrForeignKeyViolation", err)
+	}
+	if got, _ := r.User.GetByID(ctx, "u1"); got.TeamName != "backend" || got.Version != u.Version {
+		t.Errorf("rejected save changed the user: %+v", got)
+	}
+}
+
+func testPrRequiresAuthor(t *testing.T, r Repositories) {
+	seedTeam(t, r)
+
+	err := r.Pr.Create(context.Background(), model.NewPr("pr-1", "Orphan", "missing"))
+	if !errors.Is(err, repository.ErrForeignKeyViolation) {
+		t.Fatalf("create with a missing author error = %v, want ErrForeignKeyViolation", err)
+	}
+	if pr := getPr(t, r, "pr-1"); pr != nil {
+		t.Errorf("rejected pull request was stored: %+v", pr)
+	}
+}
+
+func testPrRequiresReviewers(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, r)
+	pr := seedPr(t, r)
+
+	pr.AssignReviewer("missing", "")
+	if err := r.Pr.Save(ctx, pr); !errors.Is(err, repository.ErrForeignKeyViolation) {
+		t.Fatalf("save with a missing reviewer error = %v, want ErrForeignKeyViolation", err)
+	}
+	if got := getPr(t, r, "pr-1"); got.Version != 1 || len(got.AssignedReviewers) != 1 {
+		t.Errorf("rejected save changed the pull request: %+v", got)
+	}
+	history, err := r.Pr.GetHistory(ctx, "pr-1")
+	if err != nil || len(history) != 2 {
+		t.Errorf("history after the rejected save has %d events, %v, want 2", len(history), err)
+	}
+}
+
+func testPrVersionConflict(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, r)
+	seedPr(t, r)
+
+	first, second := getPr(t, r, "pr-1"), getPr(t, r, "pr-1")
+	if err := first.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Pr.Save(ctx, first); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+
+	if err := second.Merge(0, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Pr.Save(ctx, second); !errors.Is(err, domain_errors.ErrVersionMismatch) {
+		t.Fatalf("stale save error = %v, want ErrVersionMismatch", err)
+	}
+	if got := getPr(t, r, "pr-1"); got.Status != model.StatusClosed || got.Version != 2 {
+		t.Errorf("stored pull request = %s version %d, want CLOSED version 2", got.Status, got.Version)
+	}
+}
+
+func testUserVersionConflict(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, r)
+
+	first, _ := r.User.GetByID(ctx, "u1")
+	second, _ := r.User.GetByID(ctx, "u1")
+	first.Deactivate()
+	if err := r.User.Save(ctx, first); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+
+	second.Username = "alice2"
+	if err := r.User.Save(ctx, second); !errors.Is(err, domain_errors.ErrVersionMismatch) {
+		t.Fatalf("stale save error = %v, want ErrVersionMismatch", err)
+	}
+	if got, _ := r.User.GetByID(ctx, "u1"); got.IsActive || got.Username != "alice" || got.Version != first.Version {
+		t.Errorf("stored user = %+v, want the first save", got)
+	}
+}
+
+func testSaveMissing(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, r)
+
+	if err := r.Pr.Save(ctx, model.NewPr("pr-x", "Missing", "u1")); !errors.Is(err, domain_errors.ErrPullRequestNotFound) {
+		t.Errorf("save of a missing pull request error = %v, want ErrPullRequestNotFound", err)
+	}
+	if err := r.User.Save(ctx, model.NewUser("ux", "nobody", "backend", true)); !errors.Is(err, domain_errors.ErrUserNotFound) {
+		t.Errorf("save of a missing user error = %v, want ErrUserNotFound", err)
+	}
+}
+
+func testTxRollback(t *testing.T, r Repositories) {
+	ctx := context.Background()
+
+	err := r.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		team := model.NewTeam("backend", []*model.User{model.NewUser("u1", "alice", "backend", true)})
+		if err := r.Team.Create(ctx, team); err != nil {
+			return err
+		}
+		if err := r.Pr.Create(ctx, model.NewPr("pr-1", "Add fallback teams", "u1")); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("WithinTx error = %v, want the error of fn", err)
+	}
+
+	if team, err := r.Team.GetByName(ctx, "backend"); err != nil || team != nil {
+		t.Errorf("team after rollback = %+v, %v, want nil", team, err)
+	}
+	if u, err := r.User.GetByID(ctx, "u1"); err != nil || u != nil {
+		t.Errorf("user after rollback = %+v, %v, want nil", u, err)
+	}
+	if pr := getPr(t, r, "pr-1"); pr != nil {
+		t.Errorf("pull request after rollback = %+v, want nil", pr)
+	}
+	if history, err := r.Pr.GetHistory(ctx, "pr-1"); err != nil || len(history) != 0 {
+		t.Errorf("history after rollback = %d events, %v, want none", len(history), err)
+	}
+}
+
+func testTxRollbackOnRepositoryError(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, r)
+	seedPr(t, r)
+	stale := getPr(t, r, "pr-1")
+
+	err := r.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		pr, err := r.Pr.GetByIDForUpdate(ctx, "pr-1")
+		if err != nil {
+			return err
+		}
+		if err := pr.Close(); err != nil {
+			return err
+		}
+		if err := r.Pr.Save(ctx, pr); err != nil {
+			return err
+		}
+		// The first save bumped the version, so this one conflicts.
+		if err := stale.Merge(0, ""); err != nil {
+			return err
+		}
+		return r.Pr.Save(ctx, stale)
+	})
+	if !errors.Is(err, domain_errors.ErrVersionMismatch) {
+		t.Fatalf("WithinTx error = %v, want ErrVersionMismatch", err)
+	}
+
+	if got := getPr(t, r, "pr-1"); got.Status != model.StatusOpen || got.Version != 1 {
+		t.Errorf("pull request after rollback = %s version %d, want OPEN version 1", got.Status, got.Version)
+	}
+}
+
+func testTxCommit(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, r)
+
+	err := r.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := r.Pr.Create(ctx, model.NewPr("pr-1", "First", "u1")); err != nil {
+			return err
+		}
+		// Nested units of work join the outer one.
+		return r.Tx.WithinTx(ctx, func(ctx context.Context) error {
+			return r.Pr.Create(ctx, model.NewPr("pr-2", "Second", "u2"))
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithinTx: %v", err)
+	}
+	if getPr(t, r, "pr-1") == nil || getPr(t, r, "pr-2") == nil {
+		t.Errorf("pull requests of the committed unit of work are missing")
+	}
+}
+
+func reserve(t *testing.T, r Repositories, rec *model.IdempotencyRecord) *model.IdempotencyRecord {
+	t.Helper()
+	existing, err := r.Idempotency.Reserve(context.Background(), rec)
+	if err != nil {
+		t.Fatalf("reserve %s: %v", rec.Key, err)
+	}
+	return existing
+}
+
+func testIdempotencyKeyInProgress(t *testing.T, r Repositories) {
+	if existing := reserve(t, r, model.NewIdempotencyRecord("k", "h1", time.Hour, time.Minute)); existing != nil {
+		t.Fatalf("first Reserve returned %+v, want nil", existing)
+	}
+
+	existing := reserve(t, r, model.NewIdempotencyRecord("k", "h2", time.Hour, time.Minute))
+	if existing == nil || existing.Completed() || existing.RequestHash != "h1" {
+		t.Fatalf("second Reserve = %+v, want the first reservation in progress", existing)
+	}
+}
+
+func testIdempotencyAbandonedKeyIsTakenOver(t *testing.T, r Repositories) {
+	// The lease ended long enough ago to tolerate clock skew with the database.
+	reserve(t, r, model.NewIdempotencyRecord("k", "h1", time.Hour, -time.Minute))
+
+	if existing := reserve(t, r, model.NewIdempotencyRecord("k", "h1", time.Hour, time.Minute)); existing != nil {
+		t.Fatalf("Reserve after the lease = %+v, want the key taken over", existing)
+	}
+	existing := reserve(t, r, model.NewIdempotencyRecord("k", "h1", time.Hour, time.Minute))
+	if existing == nil || existing.Completed() {
+		t.Fatalf("Reserve after the takeover = %+v, want the new reservation in progress", existing)
+	}
+}
+
+func testIdempotencyCompletedKeyOutlivesLease(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	rec := model.NewIdempotencyRecord("k", "h1", time.Hour, -time.Minute)
+	reserve(t, r, rec)
+	rec.StatusCode = 201
+	rec.ContentType = "application/json"
+	rec.ETag = `"1"`
+	rec.Body = []byte(`{"ok":true}`)
+	if err := r.Idempotency.Complete(ctx, rec); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+
+	existing := reserve(t, r, model.NewIdempotencyRecord("k", "h1", time.Hour, time.Minute))
+	if existing == nil || existing.StatusCode != 201 || existing.ETag != `"1"` || string(existing.Body) != `{"ok":true}` {
+		t.Fatalf("Reserve of a completed key = %+v, want the stored response", existing)
+	}
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sync"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// fileClient is an OTLP client that appends every export request to a file as
+// one line of OTLP/JSON, the format read by the collector's otlpjsonfile
+// receiver and by local trace viewers.
+type fileClient struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func newFileClient(f *os.File) *fileClient {
+	return &fileClient{f: f}
+}
+
+func (c *fileClient) Start(context.Context) error {
+	return nil
+}
+
+func (c *fileClient) Stop(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.f.Close()
+}
+
+func (c *fileClient) UploadTraces(_ context.Context, spans []*tracepb.ResourceSpans) error {
+	data, err := protojson.Marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: spans})
+	if err != nil {
+		return err
+	}
+	data, err = hexIDs(data)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err = c.f.Write(append(data, '\n'))
+	return err
+}
+
+// hexIDs rewrites trace and span IDs from the base64 that protojson produces
+// for bytes fields to the hex encoding OTLP/JSON requires.
+func hexIDs(data []byte) ([]byte, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	walkIDs(doc)
+	return json.Marshal(doc)
+}
+
+func walkIDs(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			switch key {
+			case "traceId", "spanId", "parentSpanId":
+				if s, ok := value.(string); ok {
+					if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+						v[key] = hex.EncodeToString(b)
+					}
+				}
+			default:
+				walkIDs(value)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			walkIDs(item)
+		}
+	}
+}
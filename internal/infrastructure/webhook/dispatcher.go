@@ -0,0 +1,205 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"test/internal/domain/model"
+	"test/internal/domain/repository"
+	"time"
+)
+
+const (
+	HeaderSignature = "X-Signature-256"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Lease is how long a claimed delivery is hidden from other dispatchers.
+	Lease          time.Duration
+	RequestTimeout time.Duration
+	// MaxAttempts is the number of attempts after which a delivery becomes DEAD.
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		PollInterval:   time.Second,
+		BatchSize:      50,
+		Lease:          time.Minute,
+		RequestTimeout: 10 * time.Second,
+		MaxAttempts:    8,
+		BaseBackoff:    time.Second,
+		MaxBackoff:     time.Hour,
+	}
+}
+
+// Dispatcher moves outbox events to webhook deliveries and sends them.
+type Dispatcher struct {
+	repo   repository.OutboxRepository
+	client *http.Client
+	cfg    Config
+
+	running  atomic.Bool
+	lastBeat atomic.Int64
+}
+
+func NewDispatcher(repo repository.OutboxRepository, cfg Config) *Dispatcher {
+	return &Dispatcher{
+		repo:   repo,
+		client: &http.Client{Timeout: cfg.RequestTimeout},
+		cfg:    cfg,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	d.running.Store(true)
+	defer d.running.Store(false)
+
+	for {
+		d.beat()
+		d.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) tick(ctx context.Context) {
+	if _, err := d.repo.FanOut(ctx, d.cfg.BatchSize); err != nil {
+		slog.ErrorContext(ctx, "webhook: fan out", "error", err)
+	}
+
+	deliveries, err := d.repo.ClaimDeliveries(ctx, d.cfg.BatchSize, d.cfg.Lease)
+	if err != nil {
+		slog.ErrorContext(ctx, "webhook: claim deliveries", "error", err)
+		return
+	}
+
+	// Deliveries left over on shutdown keep their lease and are retried once it expires.
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return
+		}
+		d.deliver(ctx, delivery)
+		d.beat()
+	}
+}
+
+func (d *Dispatcher) beat() {
+	d.lastBeat.Store(time.Now().UnixNano())
+}
+
+// Check reports whether Run is running and making progress. A single poll or
+// delivery is bounded by the request timeout, so a longer silence means the
+// loop is stuck.
+func (d *Dispatcher) Check(_ context.Context) error {
+	if !d.running.Load() {
+		return errors.New("dispatcher is not running")
+	}
+
+	stallAfter := 3 * max(d.cfg.PollInterval, d.cfg.RequestTimeout)
+	if since := time.Since(time.Unix(0, d.lastBeat.Load())); since > stallAfter {
+		return fmt.Errorf("dispatcher made no progress for %s", since.Round(time.Second))
+	}
+	return nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *model.WebhookDelivery) {
+	sendErr := d.send(ctx, delivery)
+	if sendErr == nil {
+		if err := d.repo.MarkDelivered(ctx, delivery.ID); err != nil {
+			slog.ErrorContext(ctx, "webhook: mark delivery delivered", "delivery_id", delivery.ID, "error", err)
+		}
+		return
+	}
+
+	if ctx.Err() != nil {
+		// Cut off by shutdown rather than failed by the receiver.
+		return
+	}
+
+	var next *time.Time
+	attempts := delivery.Attempts + 1
+	if attempts < d.cfg.MaxAttempts {
+		at := time.Now().Add(d.backoff(attempts))
+		next = &at
+	}
+
+	if next == nil {
+		slog.ErrorContext(ctx, "webhook: delivery dead", "delivery_id", delivery.ID, "subscription_id", delivery.SubscriptionID,
+			"event_type", delivery.EventType, "attempts", attempts, "error", sendErr)
+	} else {
+		slog.WarnContext(ctx, "webhook: delivery failed", "delivery_id", delivery.ID, "subscription_id", delivery.SubscriptionID,
+			"event_type", delivery.EventType, "attempts", attempts, "next_attempt_at", *next, "error", sendErr)
+	}
+
+	if err := d.repo.MarkFailed(ctx, delivery.ID, sendErr.Error(), next); err != nil {
+		slog.ErrorContext(ctx, "webhook: mark delivery failed", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery *model.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// backoff returns the delay before the given attempt: BaseBackoff doubled per
+// failed attempt, capped at MaxBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= d.cfg.MaxBackoff {
+			return d.cfg.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// Sign returns the value of the signature header for payload: "sha256=" followed
+// by the hex HMAC-SHA256 of the body keyed with the subscription secret.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}